@@ -0,0 +1,17 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package spreadsheet
+
+// ColumnName returns the name of the column (A, B, ..., Z, AA, AB, ...)
+// for the zero-based column index.
+func ColumnName(i int) string {
+	var a [8]byte
+	n := len(a)
+	for i++; i > 0; i = (i - 1) / 26 {
+		n--
+		a[n] = byte('A' + (i-1)%26)
+	}
+	return string(a[n:])
+}
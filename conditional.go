@@ -0,0 +1,164 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package spreadsheet
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ConditionalFormatter is implemented by the Sheets that can format their cells conditionally.
+type ConditionalFormatter interface {
+	// AddConditionalFormat adds the rule to the cells of the range, such as "C2:C1000".
+	// The rules added earlier take precedence.
+	//
+	// It should be called before the rows of the range are appended.
+	AddConditionalFormat(ref string, cf ConditionalFormat) error
+}
+
+// ConditionType is the type of a conditional format rule.
+type ConditionType uint8
+
+const (
+	// ConditionCellValue applies the Style to the cells whose value compares to Value (and Value2) by the Operator.
+	ConditionCellValue = ConditionType(iota + 1)
+	// ConditionFormula applies the Style to the cells where the Formula is true.
+	ConditionFormula
+	// ConditionColorScale colors the cells' background on a scale from MinColor (through MidColor) to MaxColor.
+	ConditionColorScale
+	// ConditionDataBar draws bars of BarColor in the cells, proportional to their values.
+	ConditionDataBar
+)
+
+func (t ConditionType) String() string {
+	switch t {
+	case ConditionCellValue:
+		return "cellValue"
+	case ConditionFormula:
+		return "formula"
+	case ConditionColorScale:
+		return "colorScale"
+	case ConditionDataBar:
+		return "dataBar"
+	default:
+		return "ConditionType(" + strconv.Itoa(int(t)) + ")"
+	}
+}
+
+// Operator is the comparison of ConditionCellValue.
+type Operator uint8
+
+const (
+	OperatorEqual = Operator(iota + 1)
+	OperatorNotEqual
+	OperatorLess
+	OperatorLessOrEqual
+	OperatorGreater
+	OperatorGreaterOrEqual
+	// OperatorBetween is true for the values between Value and Value2, inclusive.
+	OperatorBetween
+	OperatorNotBetween
+)
+
+func (op Operator) String() string {
+	switch op {
+	case OperatorEqual:
+		return "="
+	case OperatorNotEqual:
+		return "<>"
+	case OperatorLess:
+		return "<"
+	case OperatorLessOrEqual:
+		return "<="
+	case OperatorGreater:
+		return ">"
+	case OperatorGreaterOrEqual:
+		return ">="
+	case OperatorBetween:
+		return "between"
+	case OperatorNotBetween:
+		return "not between"
+	default:
+		return "Operator(" + strconv.Itoa(int(op)) + ")"
+	}
+}
+
+// ConditionalFormat is a conditional format rule.
+//
+// The formulas use the A1 reference style and comma separated arguments, as Excel does,
+// such as `AND($C2<>"", $C2<TODAY())`. The relative references are relative to the
+// top-left cell of the range.
+//
+// The colors are in "#RRGGBB" form.
+type ConditionalFormat struct {
+	// Value and Value2 are the operands of ConditionCellValue, as formulas: "0", "TODAY()" or `"text"`.
+	// Value2 is needed by OperatorBetween and OperatorNotBetween only.
+	Value, Value2 string
+	// Formula is the condition of ConditionFormula.
+	Formula string
+	// MinColor, MidColor and MaxColor are the colors of ConditionColorScale - MidColor is optional.
+	MinColor, MidColor, MaxColor string
+	// BarColor is the color of ConditionDataBar.
+	BarColor string
+	// Style is applied by ConditionCellValue and ConditionFormula:
+	// its FontBold, FontColor and BackgroundColor are used.
+	Style    Style
+	Type     ConditionType
+	Operator Operator
+}
+
+// ErrInvalidConditionalFormat is returned for an incomplete or inconsistent ConditionalFormat.
+var ErrInvalidConditionalFormat = errors.New("invalid conditional format")
+
+// Check the conditional format.
+func (cf ConditionalFormat) Check() error {
+	var colors []string
+	switch cf.Type {
+	case ConditionCellValue:
+		if cf.Operator < OperatorEqual || cf.Operator > OperatorNotBetween {
+			return fmt.Errorf("%w: unknown operator %v", ErrInvalidConditionalFormat, cf.Operator)
+		}
+		if cf.Value == "" {
+			return fmt.Errorf("%w: no Value", ErrInvalidConditionalFormat)
+		}
+		if (cf.Operator == OperatorBetween || cf.Operator == OperatorNotBetween) && cf.Value2 == "" {
+			return fmt.Errorf("%w: %v without Value2", ErrInvalidConditionalFormat, cf.Operator)
+		}
+		colors = []string{cf.Style.FontColor, cf.Style.BackgroundColor}
+	case ConditionFormula:
+		if cf.Formula == "" {
+			return fmt.Errorf("%w: no Formula", ErrInvalidConditionalFormat)
+		}
+		colors = []string{cf.Style.FontColor, cf.Style.BackgroundColor}
+	case ConditionColorScale:
+		if cf.MinColor == "" || cf.MaxColor == "" {
+			return fmt.Errorf("%w: %v without MinColor and MaxColor", ErrInvalidConditionalFormat, cf.Type)
+		}
+		colors = []string{cf.MinColor, cf.MidColor, cf.MaxColor}
+	case ConditionDataBar:
+		if cf.BarColor == "" {
+			return fmt.Errorf("%w: %v without BarColor", ErrInvalidConditionalFormat, cf.Type)
+		}
+		colors = []string{cf.BarColor}
+	default:
+		return fmt.Errorf("%w: unknown type %v", ErrInvalidConditionalFormat, cf.Type)
+	}
+	for _, c := range colors {
+		if c != "" && !isColor(c) {
+			return fmt.Errorf("%w: color %q is not #RRGGBB", ErrInvalidConditionalFormat, c)
+		}
+	}
+	return nil
+}
+
+// isColor reports whether the color is in "#RRGGBB" form.
+func isColor(s string) bool {
+	if len(s) != 7 || s[0] != '#' {
+		return false
+	}
+	_, err := strconv.ParseUint(s[1:], 16, 32)
+	return err == nil
+}
@@ -0,0 +1,345 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package csv
+
+import (
+	"database/sql/driver"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zip"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/transform"
+
+	"github.com/UNO-SOFT/spreadsheet"
+	"github.com/UNO-SOFT/spreadsheet/internal/files"
+	"github.com/UNO-SOFT/spreadsheet/internal/spool"
+)
+
+var _ = (spreadsheet.Writer)((*CSVWriter)(nil))
+
+func init() {
+	spreadsheet.Register(spreadsheet.Format{
+		Name: "csv", Extensions: []string{".csv"}, MIMETypes: []string{"text/csv"},
+		New: func(w io.Writer, o spreadsheet.Options) (spreadsheet.Writer, error) {
+			cw, err := NewWriter(w, WithEncoding(o.Encoding))
+			if err != nil {
+				return nil, err
+			}
+			return cw, nil
+		},
+	})
+}
+
+// Option is an option for the CSV writers.
+type Option func(*options)
+
+type options struct {
+	enc        encoding.Encoding
+	separator  func(i int, name string) string
+	encName    string
+	timeFormat string
+	comma      rune
+	useCRLF    bool
+}
+
+// WithComma sets the field delimiter (default ',').
+func WithComma(comma rune) Option { return func(o *options) { o.comma = comma } }
+
+// WithCRLF sets whether the lines are terminated with \r\n.
+func WithCRLF(useCRLF bool) Option { return func(o *options) { o.useCRLF = useCRLF } }
+
+// WithEncoding sets the output encoding (default utf-8), see spreadsheet.GetEncoding.
+func WithEncoding(encName string) Option { return func(o *options) { o.encName = encName } }
+
+// WithTimeFormat sets the layout used for time.Time values.
+//
+// The default is "2006-01-02" for dates without clock, "2006-01-02 15:04:05" otherwise.
+func WithTimeFormat(layout string) Option { return func(o *options) { o.timeFormat = layout } }
+
+// WithSeparator sets the function that returns the text written before the i-th sheet
+// when all sheets are written into one stream (NewWriter).
+//
+// The default separates the sheets with an empty line.
+func WithSeparator(separator func(i int, name string) string) Option {
+	return func(o *options) { o.separator = separator }
+}
+
+func newOptions(opts []Option) (options, error) {
+	o := options{comma: ','}
+	for _, f := range opts {
+		f(&o)
+	}
+	if o.separator == nil {
+		o.separator = func(i int, _ string) string {
+			if i == 0 {
+				return ""
+			}
+			return "\n"
+		}
+	}
+	var err error
+	o.enc, err = spreadsheet.GetEncoding(o.encName)
+	return o, err
+}
+
+// CSVWriter writes the sheets as CSV.
+type CSVWriter struct {
+	create func(name string) (io.WriteCloser, error)
+	spool  *spool.Spool
+	zw     *zip.Writer
+	opts   options
+	sheets int
+	mu     sync.Mutex
+}
+
+// NewWriter returns a spreadsheet.Writer that writes all the sheets into w,
+// in the order of their creation, separated by the text returned by the
+// function set with WithSeparator.
+//
+// This writer allows concurrent writes to separate sheets.
+func NewWriter(w io.Writer, opts ...Option) (*CSVWriter, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &CSVWriter{opts: o, spool: spool.New(w)}, nil
+}
+
+// NewZipWriter returns a spreadsheet.Writer that writes a zip into w,
+// with one "name.csv" entry per sheet.
+//
+// This writer allows concurrent writes to separate sheets.
+func NewZipWriter(w io.Writer, opts ...Option) (*CSVWriter, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &CSVWriter{opts: o, spool: spool.New(w), zw: zip.NewWriter(w)}, nil
+}
+
+// NewFilesWriter returns a spreadsheet.Writer that writes each sheet
+// into the io.WriteCloser returned by create.
+//
+// This writer allows concurrent writes to separate sheets.
+func NewFilesWriter(create func(name string) (io.WriteCloser, error), opts ...Option) (*CSVWriter, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &CSVWriter{opts: o, create: create}, nil
+}
+
+// NewDirWriter returns a spreadsheet.Writer that writes each sheet
+// into the "name.csv" file in the dir directory.
+//
+// This writer allows concurrent writes to separate sheets.
+func NewDirWriter(dir string, opts ...Option) (*CSVWriter, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return NewFilesWriter(func(name string) (io.WriteCloser, error) {
+		return os.Create(filepath.Join(dir, FileName(name)))
+	}, opts...)
+}
+
+// FileName returns the file name for the sheet name: the path separators
+// and other problematic characters are replaced, and ".csv" is appended.
+func FileName(name string) string { return files.Name(name, ".csv") }
+
+// Close the writer: copies the finished sheets and closes the zip.
+//
+// Does not close the underlying io.Writer.
+func (cw *CSVWriter) Close() error {
+	if cw == nil {
+		return nil
+	}
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+	sp, zw := cw.spool, cw.zw
+	cw.spool, cw.zw, cw.create = nil, nil, nil
+	if sp != nil {
+		if err := sp.Close(); err != nil {
+			return err
+		}
+	}
+	if zw != nil {
+		return zw.Close()
+	}
+	return nil
+}
+
+// NewSheet returns a new sheet, writing the column names as header, if any is not empty.
+func (cw *CSVWriter) NewSheet(name string, cols []spreadsheet.Column) (spreadsheet.Sheet, error) {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+	i := cw.sheets
+	cw.sheets++
+	var w io.WriteCloser
+	var err error
+	switch {
+	case cw.create != nil:
+		w, err = cw.create(name)
+	case cw.zw != nil:
+		zw := cw.zw
+		w, err = cw.spool.NewPart(func(io.Writer) (io.Writer, error) {
+			return zw.CreateHeader(&zip.FileHeader{
+				Name: FileName(name), Method: zip.Deflate, Modified: time.Now(),
+			})
+		})
+	case cw.spool != nil:
+		w, err = cw.spool.NewPart(nil)
+	default:
+		return nil, os.ErrClosed
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sheet := &CSVSheet{Name: name, opts: &cw.opts, dst: w}
+	sheet.enc = cw.encode(w)
+	if cw.create == nil && cw.zw == nil {
+		if sep := cw.opts.separator(i, name); sep != "" {
+			if _, err = io.WriteString(sheet.enc, sep); err != nil {
+				sheet.enc.Close()
+				w.Close()
+				return nil, err
+			}
+		}
+	}
+	sheet.w = csv.NewWriter(sheet.enc)
+	sheet.w.Comma, sheet.w.UseCRLF = cw.opts.comma, cw.opts.useCRLF
+	var hasHeader bool
+	for _, c := range cols {
+		if c.Name != "" {
+			hasHeader = true
+			break
+		}
+	}
+	if hasHeader {
+		for _, c := range cols {
+			sheet.record = append(sheet.record, c.Name)
+		}
+		if err = sheet.w.Write(sheet.record); err != nil {
+			sheet.Close()
+			return nil, err
+		}
+	}
+	return sheet, nil
+}
+
+// encode returns a writer that encodes to the output encoding.
+//
+// The returned writer must be closed to flush the remaining bytes.
+func (cw *CSVWriter) encode(w io.Writer) io.WriteCloser {
+	if cw.opts.enc == nil {
+		return nopCloser{w}
+	}
+	return transform.NewWriter(w, encoding.ReplaceUnsupported(cw.opts.enc.NewEncoder()))
+}
+
+// CSVSheet is a sheet written as CSV.
+type CSVSheet struct {
+	opts   *options
+	w      *csv.Writer
+	enc    io.WriteCloser
+	dst    io.WriteCloser
+	Name   string
+	record []string
+	mu     sync.Mutex
+}
+
+// AppendRow writes the values as one CSV record.
+func (cs *CSVSheet) AppendRow(values ...any) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if cs.w == nil {
+		return os.ErrClosed
+	}
+	cs.record = cs.record[:0]
+	for _, v := range values {
+		cs.record = append(cs.record, cs.opts.format(v))
+	}
+	return cs.w.Write(cs.record)
+}
+
+// Close flushes the sheet.
+func (cs *CSVSheet) Close() error {
+	if cs == nil {
+		return nil
+	}
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	w, enc, dst := cs.w, cs.enc, cs.dst
+	cs.w, cs.enc, cs.dst = nil, nil, nil
+	if w == nil {
+		return nil
+	}
+	w.Flush()
+	err := w.Error()
+	if closeErr := enc.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	if closeErr := dst.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// format the value as text.
+func (o *options) format(v any) string {
+	if vr, ok := v.(driver.Valuer); ok {
+		if vv, err := vr.Value(); err == nil {
+			v = vv
+		}
+	}
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case spreadsheet.Number:
+		return string(x)
+	case []byte:
+		return string(x)
+	case time.Time:
+		if x.IsZero() {
+			return ""
+		}
+		if o.timeFormat != "" {
+			return x.Format(o.timeFormat)
+		}
+		if x.Hour() == 0 && x.Minute() == 0 && x.Second() == 0 && x.Nanosecond() == 0 {
+			return x.Format("2006-01-02")
+		}
+		return x.Format("2006-01-02 15:04:05")
+	case bool:
+		return strconv.FormatBool(x)
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(x), 'f', -1, 32)
+	case int:
+		return strconv.Itoa(x)
+	case int64:
+		return strconv.FormatInt(x, 10)
+	case int8, int16, int32, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", x)
+	case fmt.Stringer:
+		return x.String()
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+type nopCloser struct{ io.Writer }
+
+func (nopCloser) Close() error { return nil }
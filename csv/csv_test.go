@@ -0,0 +1,66 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package csv_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/UNO-SOFT/spreadsheet"
+	"github.com/UNO-SOFT/spreadsheet/csv"
+	"github.com/UNO-SOFT/spreadsheet/spreadsheettest"
+)
+
+func TestConformance(t *testing.T) {
+	spreadsheettest.Run(t, spreadsheettest.Config{
+		New: func(w io.Writer) (spreadsheet.Writer, error) {
+			return csv.NewWriter(w, csv.WithSeparator(func(_ int, name string) string {
+				return sheetSeparator + name + "\n"
+			}))
+		},
+		Read:           func(b []byte) (spreadsheet.Reader, error) { return newStreamReader(b) },
+		DropsEmptyRows: true,
+	})
+}
+
+// sheetSeparator separates the sheets, with the sheet name, for the round-trip.
+const sheetSeparator = "\x00"
+
+// streamReader reads the sheets written into one stream, separated by sheetSeparator.
+type streamReader struct {
+	names []string
+	data  [][]byte
+}
+
+func newStreamReader(b []byte) (*streamReader, error) {
+	var sr streamReader
+	for _, part := range bytes.Split(b, []byte(sheetSeparator))[1:] {
+		name, data, ok := bytes.Cut(part, []byte("\n"))
+		if !ok {
+			return nil, fmt.Errorf("no name line in %q", part)
+		}
+		sr.names = append(sr.names, string(name))
+		sr.data = append(sr.data, data)
+	}
+	return &sr, nil
+}
+
+func (sr *streamReader) Sheets() []string { return sr.names }
+
+func (sr *streamReader) ReadRows(ctx context.Context, sheetName string, fn func(row []string) error) error {
+	i := slices.Index(sr.names, sheetName)
+	if i < 0 {
+		return fmt.Errorf("sheet %q not found", sheetName)
+	}
+	r, err := csv.NewReader(bytes.NewReader(sr.data[i]))
+	if err != nil {
+		return err
+	}
+	return r.ReadRows(ctx, "", fn)
+}
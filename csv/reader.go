@@ -0,0 +1,79 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package csv
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"golang.org/x/text/transform"
+
+	"github.com/UNO-SOFT/spreadsheet"
+)
+
+var _ = (spreadsheet.Reader)((*CSVReader)(nil))
+
+// SheetName is the name of the one sheet of a CSV.
+const SheetName = "Sheet1"
+
+// CSVReader reads a CSV as a spreadsheet with one sheet, named SheetName.
+type CSVReader struct {
+	r    *csv.Reader
+	Name string
+	mu   sync.Mutex
+}
+
+// NewReader returns a spreadsheet.Reader reading the CSV from r,
+// using the WithComma and WithEncoding options.
+//
+// The rows can be read only once.
+func NewReader(r io.Reader, opts ...Option) (*CSVReader, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	if o.enc != nil {
+		r = transform.NewReader(r, o.enc.NewDecoder())
+	}
+	cr := csv.NewReader(r)
+	cr.Comma, cr.FieldsPerRecord, cr.ReuseRecord = o.comma, -1, true
+	return &CSVReader{r: cr, Name: SheetName}, nil
+}
+
+// Sheets returns the one sheet's name.
+func (cr *CSVReader) Sheets() []string { return []string{cr.Name} }
+
+// ReadRows calls fn with each record of the CSV.
+func (cr *CSVReader) ReadRows(ctx context.Context, sheetName string, fn func(row []string) error) error {
+	if sheetName != cr.Name && sheetName != "" {
+		return fmt.Errorf("sheet %q not found", sheetName)
+	}
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	r := cr.r
+	cr.r = nil
+	if r == nil {
+		return errors.New("the CSV has already been read")
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		row, err := r.Read()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if err = fn(row); err != nil {
+			return err
+		}
+	}
+}
@@ -12,8 +12,12 @@ import (
 	"time"
 
 	"github.com/UNO-SOFT/spreadsheet"
-	"github.com/UNO-SOFT/spreadsheet/ods"
-	"github.com/UNO-SOFT/spreadsheet/xlsx"
+	_ "github.com/UNO-SOFT/spreadsheet/html"
+	_ "github.com/UNO-SOFT/spreadsheet/json"
+	_ "github.com/UNO-SOFT/spreadsheet/ods"
+	_ "github.com/UNO-SOFT/spreadsheet/spreadsheetml"
+	_ "github.com/UNO-SOFT/spreadsheet/text"
+	_ "github.com/UNO-SOFT/spreadsheet/xlsx"
 )
 
 func main() {
@@ -24,6 +28,7 @@ func main() {
 
 func Main() error {
 	flagEnc := flag.String("charset", spreadsheet.EncName, "csv charset name")
+	flagFormat := flag.String("format", "", "output format name, extension or MIME type (default: by the output file's extension, or ods)")
 	flag.Parse()
 
 	fn := flag.Arg(0)
@@ -35,15 +40,17 @@ func Main() error {
 		}
 	}
 	defer fh.Close()
-	var w spreadsheet.Writer
-	if strings.HasSuffix(fn, ".xlsx") {
-		w = xlsx.NewWriter(fh)
-	} else {
-		var err error
-		if w, err = ods.NewWriter(fh); err != nil {
-			return err
+	format := *flagFormat
+	if format == "" {
+		format = "ods"
+		if _, ok := spreadsheet.LookupFormat(fn); ok {
+			format = fn
 		}
 	}
+	w, err := spreadsheet.NewWriterFor(format, fh)
+	if err != nil {
+		return err
+	}
 
 	for i, fn := range flag.Args()[1:] {
 		sheetName := fmt.Sprintf("Sheet%d", i+1)
@@ -0,0 +1,194 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package html
+
+import (
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	qt "github.com/valyala/quicktemplate"
+
+	"github.com/UNO-SOFT/spreadsheet"
+	"github.com/UNO-SOFT/spreadsheet/internal/format"
+	"github.com/UNO-SOFT/spreadsheet/internal/spool"
+)
+
+//go:generate qtc
+
+var _ = (spreadsheet.Writer)((*HTMLWriter)(nil))
+
+func init() {
+	spreadsheet.Register(spreadsheet.Format{
+		Name: "html", Extensions: []string{".html", ".htm"}, MIMETypes: []string{"text/html"},
+		New: func(w io.Writer, o spreadsheet.Options) (spreadsheet.Writer, error) {
+			hw, err := NewWriter(w, WithTitle(o.Title))
+			if err != nil {
+				return nil, err
+			}
+			return hw, nil
+		},
+	})
+}
+
+// Option is an option for the HTML writer.
+type Option func(*options)
+
+type options struct {
+	title string
+}
+
+// WithTitle sets the title of the HTML document.
+func WithTitle(title string) Option { return func(o *options) { o.title = title } }
+
+// HTMLWriter writes a standalone HTML document, with one table per sheet.
+type HTMLWriter struct {
+	w     io.Writer
+	spool *spool.Spool
+	mu    sync.Mutex
+}
+
+// NewWriter returns a spreadsheet.Writer that writes a HTML document into w.
+//
+// The styles are inlined, so the output can be embedded into an email body.
+//
+// This writer allows concurrent writes to separate sheets.
+func NewWriter(w io.Writer, opts ...Option) (*HTMLWriter, error) {
+	var o options
+	for _, f := range opts {
+		f(&o)
+	}
+	W := qt.AcquireWriter(w)
+	streambeginDocument(W, o.title)
+	qt.ReleaseWriter(W)
+	return &HTMLWriter{w: w, spool: spool.New(w)}, nil
+}
+
+// Close the HTMLWriter: copies the sheets and finishes the document.
+//
+// Does not close the underlying io.Writer.
+func (hw *HTMLWriter) Close() error {
+	if hw == nil {
+		return nil
+	}
+	hw.mu.Lock()
+	defer hw.mu.Unlock()
+	w, sp := hw.w, hw.spool
+	hw.w, hw.spool = nil, nil
+	if w == nil {
+		return nil
+	}
+	if err := sp.Close(); err != nil {
+		return err
+	}
+	W := qt.AcquireWriter(w)
+	streamendDocument(W)
+	qt.ReleaseWriter(W)
+	return nil
+}
+
+// NewSheet starts a new table, with the column names in the header.
+func (hw *HTMLWriter) NewSheet(name string, cols []spreadsheet.Column) (spreadsheet.Sheet, error) {
+	for _, c := range cols {
+		if err := c.Check(); err != nil {
+			return nil, err
+		}
+	}
+	hw.mu.Lock()
+	defer hw.mu.Unlock()
+	if hw.spool == nil {
+		return nil, os.ErrClosed
+	}
+	part, err := hw.spool.NewPart(nil)
+	if err != nil {
+		return nil, err
+	}
+	sheet := &HTMLSheet{Name: name, part: part, w: qt.AcquireWriter(part), cols: cols}
+	var header []cell
+	for _, c := range cols {
+		if c.Name != "" {
+			header = make([]cell, len(cols))
+			for i, c := range cols {
+				header[i] = cell{Text: c.Name, Style: "font-weight:normal"}
+				if c.Header.FontBold {
+					header[i].Style = "font-weight:bold"
+				}
+			}
+			break
+		}
+	}
+	streambeginSheet(sheet.w, name, header)
+	return sheet, nil
+}
+
+type cell struct {
+	Text, Style string
+	Link        bool
+}
+
+// HTMLSheet is a table in the HTML document.
+type HTMLSheet struct {
+	part  *spool.Part
+	w     *qt.Writer
+	Name  string
+	cols  []spreadsheet.Column
+	cells []cell
+	mu    sync.Mutex
+}
+
+// AppendRow appends a row to the table, the values formatted according to the column's style.
+func (hs *HTMLSheet) AppendRow(values ...any) error {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	if hs.w == nil {
+		return os.ErrClosed
+	}
+	hs.cells = hs.cells[:0]
+	for i, v := range values {
+		var style spreadsheet.Style
+		if i < len(hs.cols) {
+			style = hs.cols[i].Column
+		}
+		text, kind := format.Text(v, style.Format)
+		c := cell{Text: text,
+			Link: kind == format.String && (strings.HasPrefix(text, "https://") || strings.HasPrefix(text, "http://")),
+		}
+		var css []string
+		if style.FontBold {
+			css = append(css, "font-weight:bold")
+		}
+		if style.FontColor != "" {
+			css = append(css, "color:"+style.FontColor)
+		}
+		if style.BackgroundColor != "" {
+			css = append(css, "background-color:"+style.BackgroundColor)
+		}
+		if kind == format.Number {
+			css = append(css, "text-align:right")
+		}
+		c.Style = strings.Join(css, ";")
+		hs.cells = append(hs.cells, c)
+	}
+	streamrow(hs.w, hs.cells)
+	return nil
+}
+
+// Close the table.
+func (hs *HTMLSheet) Close() error {
+	if hs == nil {
+		return nil
+	}
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+	W, part := hs.w, hs.part
+	hs.w, hs.part = nil, nil
+	if W == nil {
+		return nil
+	}
+	streamendSheet(W)
+	qt.ReleaseWriter(W)
+	return part.Close()
+}
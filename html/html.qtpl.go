@@ -0,0 +1,281 @@
+// Code generated by qtc from "html.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:1
+package html
+
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:1
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:1
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:1
+func streambeginDocument(qw422016 *qt422016.Writer, title string) {
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:1
+	qw422016.N().S(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>`)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:5
+	qw422016.E().S(title)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:5
+	qw422016.N().S(`</title>
+</head>
+<body>
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:8
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:8
+func writebeginDocument(qq422016 qtio422016.Writer, title string) {
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:8
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:8
+	streambeginDocument(qw422016, title)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:8
+	qt422016.ReleaseWriter(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:8
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:8
+func beginDocument(title string) string {
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:8
+	qb422016 := qt422016.AcquireByteBuffer()
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:8
+	writebeginDocument(qb422016, title)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:8
+	qs422016 := string(qb422016.B)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:8
+	qt422016.ReleaseByteBuffer(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:8
+	return qs422016
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:8
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:10
+func streambeginSheet(qw422016 *qt422016.Writer, name string, header []cell) {
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:10
+	qw422016.N().S(`<table border="1" cellspacing="0" cellpadding="3" style="border-collapse:collapse">
+<caption>`)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:11
+	qw422016.E().S(name)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:11
+	qw422016.N().S(`</caption>
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:12
+	if len(header) != 0 {
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:12
+		qw422016.N().S(`<thead>
+<tr>`)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:13
+		for _, c := range header {
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:13
+			qw422016.N().S(`<th`)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:13
+			if c.Style != "" {
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:13
+				qw422016.N().S(` style="`)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:13
+				qw422016.E().S(c.Style)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:13
+				qw422016.N().S(`"`)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:13
+			}
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:13
+			qw422016.N().S(`>`)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:13
+			qw422016.E().S(c.Text)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:13
+			qw422016.N().S(`</th>`)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:13
+		}
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:13
+		qw422016.N().S(`</tr>
+</thead>
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:15
+	}
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:15
+	qw422016.N().S(`<tbody>
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:16
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:16
+func writebeginSheet(qq422016 qtio422016.Writer, name string, header []cell) {
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:16
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:16
+	streambeginSheet(qw422016, name, header)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:16
+	qt422016.ReleaseWriter(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:16
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:16
+func beginSheet(name string, header []cell) string {
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:16
+	qb422016 := qt422016.AcquireByteBuffer()
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:16
+	writebeginSheet(qb422016, name, header)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:16
+	qs422016 := string(qb422016.B)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:16
+	qt422016.ReleaseByteBuffer(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:16
+	return qs422016
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:16
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:18
+func streamrow(qw422016 *qt422016.Writer, cells []cell) {
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:18
+	qw422016.N().S(`<tr>`)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:18
+	for _, c := range cells {
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:18
+		qw422016.N().S(`<td`)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:18
+		if c.Style != "" {
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:18
+			qw422016.N().S(` style="`)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:18
+			qw422016.E().S(c.Style)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:18
+			qw422016.N().S(`"`)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:18
+		}
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:18
+		qw422016.N().S(`>`)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:19
+		if c.Link {
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:19
+			qw422016.N().S(`<a href="`)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:19
+			qw422016.E().S(c.Text)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:19
+			qw422016.N().S(`">`)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:19
+			qw422016.E().S(c.Text)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:19
+			qw422016.N().S(`</a>`)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:20
+		} else {
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:20
+			qw422016.E().S(c.Text)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:21
+		}
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:21
+		qw422016.N().S(`</td>`)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:21
+	}
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:21
+	qw422016.N().S(`</tr>
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:22
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:22
+func writerow(qq422016 qtio422016.Writer, cells []cell) {
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:22
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:22
+	streamrow(qw422016, cells)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:22
+	qt422016.ReleaseWriter(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:22
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:22
+func row(cells []cell) string {
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:22
+	qb422016 := qt422016.AcquireByteBuffer()
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:22
+	writerow(qb422016, cells)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:22
+	qs422016 := string(qb422016.B)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:22
+	qt422016.ReleaseByteBuffer(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:22
+	return qs422016
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:22
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:24
+func streamendSheet(qw422016 *qt422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:24
+	qw422016.N().S(`</tbody>
+</table>
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:26
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:26
+func writeendSheet(qq422016 qtio422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:26
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:26
+	streamendSheet(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:26
+	qt422016.ReleaseWriter(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:26
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:26
+func endSheet() string {
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:26
+	qb422016 := qt422016.AcquireByteBuffer()
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:26
+	writeendSheet(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:26
+	qs422016 := string(qb422016.B)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:26
+	qt422016.ReleaseByteBuffer(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:26
+	return qs422016
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:26
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:28
+func streamendDocument(qw422016 *qt422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:28
+	qw422016.N().S(`</body>
+</html>
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:30
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:30
+func writeendDocument(qq422016 qtio422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:30
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:30
+	streamendDocument(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:30
+	qt422016.ReleaseWriter(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:30
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:30
+func endDocument() string {
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:30
+	qb422016 := qt422016.AcquireByteBuffer()
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:30
+	writeendDocument(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:30
+	qs422016 := string(qb422016.B)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:30
+	qt422016.ReleaseByteBuffer(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:30
+	return qs422016
+//line src/github.com/UNO-SOFT/spreadsheet/html/html.qtpl:30
+}
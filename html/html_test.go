@@ -0,0 +1,99 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package html_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/UNO-SOFT/spreadsheet"
+	"github.com/UNO-SOFT/spreadsheet/html"
+	"github.com/UNO-SOFT/spreadsheet/spreadsheettest"
+)
+
+func TestConformance(t *testing.T) {
+	spreadsheettest.Run(t, spreadsheettest.Config{
+		New:   func(w io.Writer) (spreadsheet.Writer, error) { return html.NewWriter(w) },
+		Style: cellStyle,
+	})
+}
+
+// cellStyle returns the style of the table cell from its style attribute.
+func cellStyle(data []byte, sheet string, row, col int) (spreadsheet.Style, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.Strict, dec.AutoClose, dec.Entity = false, xml.HTMLAutoClose, xml.HTMLEntity
+	var inCaption, inSheet bool
+	r, c := -1, -1
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return spreadsheet.Style{}, fmt.Errorf("%s[%d,%d]: %w", sheet, row, col, err)
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			switch tok.Name.Local {
+			case "table":
+				inSheet = false
+			case "caption":
+				inCaption = true
+			case "tr":
+				if inSheet {
+					r, c = r+1, -1
+				}
+			case "td", "th":
+				if c++; !inSheet || r != row || c != col {
+					continue
+				}
+				var s spreadsheet.Style
+				for _, a := range tok.Attr {
+					if a.Name.Local != "style" {
+						continue
+					}
+					for _, decl := range strings.Split(a.Value, ";") {
+						k, v, _ := strings.Cut(decl, ":")
+						switch strings.TrimSpace(k) {
+						case "font-weight":
+							s.FontBold = strings.TrimSpace(v) == "bold"
+						case "color":
+							s.FontColor = strings.TrimSpace(v)
+						case "background-color":
+							s.BackgroundColor = strings.TrimSpace(v)
+						}
+					}
+				}
+				return s, nil
+			}
+		case xml.EndElement:
+			if tok.Name.Local == "caption" {
+				inCaption = false
+			}
+		case xml.CharData:
+			if inCaption && string(tok) == sheet {
+				inSheet = true
+			}
+		}
+	}
+}
+
+func TestInvalidColor(t *testing.T) {
+	w, err := html.NewWriter(io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	for _, col := range []spreadsheet.Column{
+		{Name: "header", Header: spreadsheet.Style{FontColor: "red"}},
+		{Name: "column", Column: spreadsheet.Style{BackgroundColor: "#12345"}},
+	} {
+		if _, err = w.NewSheet("Colors", []spreadsheet.Column{col}); !errors.Is(err, spreadsheet.ErrInvalidStyle) {
+			t.Errorf("%s: got %v, wanted %v", col.Name, err, spreadsheet.ErrInvalidStyle)
+		}
+	}
+}
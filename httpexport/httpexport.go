@@ -0,0 +1,308 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package httpexport serves spreadsheets for download,
+// in the format negotiated from the request's Accept header,
+// streamed directly into the response.
+package httpexport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/UNO-SOFT/spreadsheet"
+)
+
+// DefaultFormat is the format used when the client accepts anything.
+const DefaultFormat = "ods"
+
+// FormatParam is the query parameter that overrides the Accept header,
+// such as "?format=xlsx".
+const FormatParam = "format"
+
+// FillFunc writes the sheets into w - it should stop when ctx is canceled.
+//
+// The writer is closed after FillFunc returns.
+type FillFunc func(ctx context.Context, w spreadsheet.Writer) error
+
+// Option is an option of the handler.
+type Option func(*options)
+
+type options struct {
+	defaultFormat string
+	formats       []string
+	writerOpts    []spreadsheet.Option
+}
+
+// WithFormats restricts the offered formats to the given (registered) ones,
+// in the order of preference.
+func WithFormats(names ...string) Option { return func(o *options) { o.formats = names } }
+
+// WithDefaultFormat sets the format used when the client accepts anything (default "ods").
+func WithDefaultFormat(name string) Option { return func(o *options) { o.defaultFormat = name } }
+
+// WithWriterOptions sets the options passed to spreadsheet.NewWriterFor.
+func WithWriterOptions(opts ...spreadsheet.Option) Option {
+	return func(o *options) { o.writerOpts = opts }
+}
+
+// ErrNotAcceptable is returned when no offered format is acceptable for the client.
+var ErrNotAcceptable = errors.New("not acceptable")
+
+// Handler returns a http.Handler that serves the spreadsheet filled by fill,
+// named baseName plus the format's extension - see Export.
+//
+// A failure before anything has been written results in an error response,
+// a failure while streaming aborts the response (see http.ErrAbortHandler),
+// so the client does not get a truncated file as if it were complete.
+func Handler(baseName string, fill FillFunc, opts ...Option) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := Export(w, r, baseName, fill, opts...)
+		if err == nil {
+			return
+		}
+		var started *StartedError
+		if errors.As(err, &started) {
+			if r.Context().Err() == nil {
+				slog.Error("export", "url", r.URL.String(), "error", err)
+			}
+			panic(http.ErrAbortHandler)
+		}
+		code := http.StatusInternalServerError
+		if errors.Is(err, ErrNotAcceptable) {
+			code = http.StatusNotAcceptable
+		}
+		http.Error(w, err.Error(), code)
+	})
+}
+
+// StartedError is returned by Export when the error occurred
+// after the response had been started.
+type StartedError struct {
+	Err error
+}
+
+func (e *StartedError) Error() string { return "response started: " + e.Err.Error() }
+func (e *StartedError) Unwrap() error { return e.Err }
+
+// Export negotiates the format, sets the Content-Type and Content-Disposition
+// (with an RFC 5987 encoded UTF-8 file name) headers, and streams the
+// spreadsheet filled by fill into w.
+//
+// The format comes from the FormatParam query parameter, or the Accept header:
+// the acceptable format with the highest quality wins, the offered formats'
+// order breaks the ties.
+//
+// The writes fail when the request's context is canceled (the client disconnected).
+//
+// Nothing is written into w when an error is returned that is not a *StartedError.
+func Export(w http.ResponseWriter, r *http.Request, baseName string, fill FillFunc, opts ...Option) error {
+	o := options{defaultFormat: DefaultFormat}
+	for _, f := range opts {
+		f(&o)
+	}
+	format, err := negotiate(r, &o)
+	if err != nil {
+		return err
+	}
+	contentType := format.MIMETypes[0]
+	if strings.HasPrefix(contentType, "text/") {
+		contentType += "; charset=utf-8"
+	}
+	h := w.Header()
+	h.Set("Content-Type", contentType)
+	h.Set("Content-Disposition", ContentDisposition(baseName+format.Extensions[0]))
+	h.Add("Vary", "Accept")
+	h.Set("X-Content-Type-Options", "nosniff")
+
+	ctx := r.Context()
+	cw := &ctxWriter{ctx: ctx, w: w}
+	sw, err := spreadsheet.NewWriterFor(format.Name, cw, o.writerOpts...)
+	if err == nil {
+		err = fill(ctx, sw)
+		if closeErr := sw.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		if cw.written {
+			return &StartedError{Err: err}
+		}
+		h.Del("Content-Disposition")
+		h.Del("Content-Type")
+		return err
+	}
+	return nil
+}
+
+// ContentDisposition returns the "attachment" Content-Disposition header value,
+// with an ASCII fallback filename and the RFC 5987 encoded UTF-8 filename*.
+func ContentDisposition(fileName string) string {
+	fallback := strings.Map(func(r rune) rune {
+		if r < ' ' || r >= 0x7f || r == '"' || r == '\\' || r == '/' {
+			return '_'
+		}
+		return r
+	}, fileName)
+	if fallback == fileName {
+		return `attachment; filename="` + fileName + `"`
+	}
+	return `attachment; filename="` + fallback + `"; filename*=UTF-8''` + extValue(fileName)
+}
+
+// extValue percent-encodes the bytes of s that are not attr-chars (RFC 5987).
+func extValue(s string) string {
+	const hex = "0123456789ABCDEF"
+	var buf strings.Builder
+	for i := range len(s) {
+		c := s[i]
+		if 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+			strings.IndexByte("!#$&+-.^_`|~", c) >= 0 {
+			buf.WriteByte(c)
+			continue
+		}
+		buf.WriteByte('%')
+		buf.WriteByte(hex[c>>4])
+		buf.WriteByte(hex[c&0xf])
+	}
+	return buf.String()
+}
+
+// negotiate returns the format to be used.
+func negotiate(r *http.Request, o *options) (spreadsheet.Format, error) {
+	offered := o.offered()
+	if name := r.URL.Query().Get(FormatParam); name != "" {
+		f, ok := spreadsheet.LookupFormat(name)
+		if ok && hasFormat(offered, f.Name) {
+			return f, nil
+		}
+		return spreadsheet.Format{}, fmt.Errorf("format %q: %w", name, ErrNotAcceptable)
+	}
+	accept := r.Header.Values("Accept")
+	if len(accept) == 0 {
+		if f, ok := spreadsheet.LookupFormat(o.defaultFormat); ok && hasFormat(offered, f.Name) {
+			return f, nil
+		}
+		if len(offered) != 0 {
+			return offered[0], nil
+		}
+	}
+	ranges := parseAccept(strings.Join(accept, ","))
+	best, bestQ, bestSpec := -1, 0.0, -1
+	for i, f := range offered {
+		for _, mt := range f.MIMETypes {
+			q, spec := quality(ranges, mt)
+			if q > bestQ || q == bestQ && q > 0 && spec > bestSpec {
+				best, bestQ, bestSpec = i, q, spec
+			}
+		}
+	}
+	if best < 0 {
+		return spreadsheet.Format{}, fmt.Errorf("%q: %w", accept, ErrNotAcceptable)
+	}
+	if bestSpec == 0 { // only */* matched: the default format is preferred
+		if f, ok := spreadsheet.LookupFormat(o.defaultFormat); ok && hasFormat(offered, f.Name) {
+			if q, _ := quality(ranges, f.MIMETypes[0]); q == bestQ {
+				return f, nil
+			}
+		}
+	}
+	return offered[best], nil
+}
+
+// offered returns the offered formats.
+func (o *options) offered() []spreadsheet.Format {
+	if len(o.formats) == 0 {
+		return spreadsheet.Formats()
+	}
+	fs := make([]spreadsheet.Format, 0, len(o.formats))
+	for _, name := range o.formats {
+		if f, ok := spreadsheet.LookupFormat(name); ok {
+			fs = append(fs, f)
+		}
+	}
+	return fs
+}
+
+func hasFormat(fs []spreadsheet.Format, name string) bool {
+	for _, f := range fs {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+type mediaRange struct {
+	Type, Subtype string
+	Q             float64
+}
+
+func parseAccept(accept string) []mediaRange {
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		mt, params, err := mime.ParseMediaType(part)
+		if err != nil {
+			continue
+		}
+		typ, sub, _ := strings.Cut(mt, "/")
+		mr := mediaRange{Type: typ, Subtype: sub, Q: 1}
+		if q, ok := params["q"]; ok {
+			if mr.Q, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		ranges = append(ranges, mr)
+	}
+	return ranges
+}
+
+// quality returns the quality of the media type, and the specificity
+// of the most specific matching range (2: exact, 1: type/*, 0: */*, -1: none).
+func quality(ranges []mediaRange, mediaType string) (float64, int) {
+	typ, sub, _ := strings.Cut(mediaType, "/")
+	q, spec := 0.0, -1
+	for _, mr := range ranges {
+		var s int
+		switch {
+		case mr.Type == typ && mr.Subtype == sub:
+			s = 2
+		case mr.Type == typ && mr.Subtype == "*":
+			s = 1
+		case mr.Type == "*" && mr.Subtype == "*":
+			s = 0
+		default:
+			continue
+		}
+		if s > spec {
+			q, spec = mr.Q, s
+		}
+	}
+	return q, spec
+}
+
+// ctxWriter fails the writes after the context is canceled.
+type ctxWriter struct {
+	ctx     context.Context
+	w       io.Writer
+	written bool
+}
+
+func (cw *ctxWriter) Write(p []byte) (int, error) {
+	if err := cw.ctx.Err(); err != nil {
+		return 0, err
+	}
+	cw.written = true
+	return cw.w.Write(p)
+}
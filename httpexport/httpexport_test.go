@@ -0,0 +1,140 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package httpexport_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/UNO-SOFT/spreadsheet"
+	_ "github.com/UNO-SOFT/spreadsheet/csv"
+	"github.com/UNO-SOFT/spreadsheet/httpexport"
+	_ "github.com/UNO-SOFT/spreadsheet/json"
+	_ "github.com/UNO-SOFT/spreadsheet/ods"
+	_ "github.com/UNO-SOFT/spreadsheet/xlsx"
+)
+
+func fill(ctx context.Context, w spreadsheet.Writer) error {
+	sheet, err := w.NewSheet("Sheet1", []spreadsheet.Column{{Name: "a"}})
+	if err != nil {
+		return err
+	}
+	if err = sheet.AppendRow(1); err != nil {
+		sheet.Close()
+		return err
+	}
+	return sheet.Close()
+}
+
+func TestNegotiate(t *testing.T) {
+	for _, tc := range []struct {
+		Name, Target, Accept string
+		Opts                 []httpexport.Option
+		Code                 int
+		ContentType          string
+	}{
+		{Name: "no accept", ContentType: "application/vnd.oasis.opendocument.spreadsheet"},
+		{Name: "any", Accept: "*/*", ContentType: "application/vnd.oasis.opendocument.spreadsheet"},
+		{Name: "any default", Accept: "*/*", Opts: []httpexport.Option{httpexport.WithDefaultFormat("csv")},
+			ContentType: "text/csv; charset=utf-8"},
+		{Name: "exact", Accept: "text/csv", ContentType: "text/csv; charset=utf-8"},
+		{Name: "quality", Accept: "text/csv;q=0.5, application/json, */*;q=0.1", ContentType: "application/json"},
+		{Name: "more specific", Accept: "*/*;q=0.9, text/csv;q=0.2",
+			Opts: []httpexport.Option{httpexport.WithFormats("csv", "json")}, ContentType: "application/json"},
+		{Name: "type wildcard", Accept: "text/*",
+			Opts: []httpexport.Option{httpexport.WithFormats("ods", "json", "csv")}, ContentType: "text/csv; charset=utf-8"},
+		{Name: "zero quality", Accept: "text/csv;q=0", Opts: []httpexport.Option{httpexport.WithFormats("csv")},
+			Code: http.StatusNotAcceptable},
+		{Name: "not acceptable", Accept: "image/png", Code: http.StatusNotAcceptable},
+		{Name: "param", Target: "?format=xlsx", Accept: "text/csv",
+			ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+		{Name: "param not offered", Target: "?format=json", Opts: []httpexport.Option{httpexport.WithFormats("csv")},
+			Code: http.StatusNotAcceptable},
+		{Name: "param unknown", Target: "?format=doc", Code: http.StatusNotAcceptable},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/export"+tc.Target, nil)
+			if tc.Accept != "" {
+				req.Header.Set("Accept", tc.Accept)
+			}
+			rec := httptest.NewRecorder()
+			httpexport.Handler("report", fill, tc.Opts...).ServeHTTP(rec, req)
+			if tc.Code == 0 {
+				tc.Code = http.StatusOK
+			}
+			if rec.Code != tc.Code {
+				t.Fatalf("got %d (%s), wanted %d", rec.Code, rec.Body, tc.Code)
+			}
+			if tc.Code != http.StatusOK {
+				if cd := rec.Header().Get("Content-Disposition"); cd != "" {
+					t.Errorf("got Content-Disposition %q for an error", cd)
+				}
+				return
+			}
+			if got := rec.Header().Get("Content-Type"); got != tc.ContentType {
+				t.Errorf("got %q, wanted %q", got, tc.ContentType)
+			}
+			if rec.Body.Len() == 0 {
+				t.Error("empty body")
+			}
+		})
+	}
+}
+
+func TestContentDisposition(t *testing.T) {
+	for _, tc := range []struct {
+		Name, Want string
+	}{
+		{"report.ods", `attachment; filename="report.ods"`},
+		{"árvíztűrő.ods", `attachment; filename="_rv_zt_r_.ods"; filename*=UTF-8''%C3%A1rv%C3%ADzt%C5%B1r%C5%91.ods`},
+		{"a:b=c@d&e f's \"g\".ods",
+			`attachment; filename="a:b=c@d&e f's _g_.ods"; filename*=UTF-8''a%3Ab%3Dc%40d&e%20f%27s%20%22g%22.ods`},
+		{"dir/ü.csv", `attachment; filename="dir__.csv"; filename*=UTF-8''dir%2F%C3%BC.csv`},
+	} {
+		if got := httpexport.ContentDisposition(tc.Name); got != tc.Want {
+			t.Errorf("%q: got\n\t%s\nwanted\n\t%s", tc.Name, got, tc.Want)
+		}
+	}
+}
+
+func TestStartedError(t *testing.T) {
+	errFill := errors.New("fill failed")
+	failing := func(ctx context.Context, w spreadsheet.Writer) error {
+		if err := fill(ctx, w); err != nil {
+			return err
+		}
+		return errFill
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/export", nil)
+	req.Header.Set("Accept", "text/csv")
+	rec := httptest.NewRecorder()
+	err := httpexport.Export(rec, req, "report", failing)
+	var started *httpexport.StartedError
+	if !errors.As(err, &started) || !errors.Is(err, errFill) {
+		t.Fatalf("got %v, wanted a StartedError of %v", err, errFill)
+	}
+
+	rec = httptest.NewRecorder()
+	func() {
+		defer func() {
+			if r := recover(); r != http.ErrAbortHandler {
+				t.Errorf("got panic %v, wanted %v", r, http.ErrAbortHandler)
+			}
+		}()
+		httpexport.Handler("report", failing).ServeHTTP(rec, req)
+	}()
+
+	// nothing has been written: an error response
+	rec = httptest.NewRecorder()
+	httpexport.Handler("report", func(context.Context, spreadsheet.Writer) error { return errFill }).ServeHTTP(rec, req)
+	if rec.Code != http.StatusInternalServerError || !strings.Contains(rec.Body.String(), errFill.Error()) {
+		t.Errorf("got %d %q, wanted an error response", rec.Code, rec.Body)
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package files helps writing the sheets into separate files.
+package files
+
+import "strings"
+
+// Name returns the file name for the sheet name: the path separators
+// and other problematic characters are replaced, and ext is appended.
+func Name(sheetName, ext string) string {
+	name := strings.Map(func(r rune) rune {
+		if r < ' ' || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, sheetName)
+	if name == "" || name == "." || name == ".." {
+		name = "_" + name
+	}
+	return name + ext
+}
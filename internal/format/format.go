@@ -0,0 +1,338 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package format renders cell values as text, honouring the
+// spreadsheet number formats (such as "#,##0.00" or "yyyy-mm-dd").
+package format
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/UNO-SOFT/spreadsheet"
+)
+
+// Kind is the kind of the value.
+type Kind uint8
+
+const (
+	// Null is a missing value.
+	Null = Kind(iota)
+	// String is text.
+	String
+	// Number is a number.
+	Number
+	// Date is a time.Time.
+	Date
+	// Bool is a boolean.
+	Bool
+)
+
+// Value returns the underlying value: driver.Valuer (such as the sql.Null* types) is resolved.
+func Value(v any) any {
+	if vr, ok := v.(driver.Valuer); ok {
+		if vv, err := vr.Value(); err == nil {
+			return vv
+		}
+	}
+	return v
+}
+
+// Text returns the text representation of v, formatted according to the
+// spreadsheet number format, and the kind of the value.
+func Text(v any, format string) (string, Kind) {
+	switch x := Value(v).(type) {
+	case nil:
+		return "", Null
+	case string:
+		return x, String
+	case spreadsheet.Number:
+		if x == "" {
+			return "", Null
+		}
+		if _, err := strconv.ParseFloat(string(x), 64); err != nil {
+			return string(x), String
+		}
+		return FormatNumber(string(x), format), Number
+	case []byte:
+		return string(x), String
+	case time.Time:
+		if x.IsZero() {
+			return "", Null
+		}
+		return x.Format(DateLayout(format, x)), Date
+	case bool:
+		return strconv.FormatBool(x), Bool
+	case float64:
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return strconv.FormatFloat(x, 'f', -1, 64), Number
+		}
+		return FormatNumber(strconv.FormatFloat(x, 'f', -1, 64), format), Number
+	case float32:
+		return FormatNumber(strconv.FormatFloat(float64(x), 'f', -1, 32), format), Number
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return FormatNumber(fmt.Sprintf("%d", x), format), Number
+	case fmt.Stringer:
+		return x.String(), String
+	default:
+		return fmt.Sprintf("%v", x), String
+	}
+}
+
+// IsNumber reports whether v is a number type (after resolving driver.Valuer).
+func IsNumber(v any) bool {
+	switch x := Value(v).(type) {
+	case float32, float64,
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64:
+		return true
+	case spreadsheet.Number:
+		_, err := strconv.ParseFloat(string(x), 64)
+		return err == nil
+	}
+	return false
+}
+
+// FormatNumber formats the number (given as decimal text) according to the
+// number format: decimal places ("0.00", "#.##"), thousands separator ("#,##0"),
+// percent ("0%") and literal prefix/suffix are honoured.
+//
+// The number is returned as is if the format is empty or it is not a number format.
+func FormatNumber(s, format string) string {
+	if i := strings.IndexByte(format, ';'); i >= 0 {
+		format = format[:i]
+	}
+	first := strings.IndexAny(format, "0#?")
+	if first < 0 || IsDateFormat(format) {
+		return s
+	}
+	last := strings.LastIndexAny(format, "0#?")
+	prefix, num, suffix := literal(format[:first]), format[first:last+1], literal(format[last+1:])
+	percent := strings.Contains(format, "%")
+	if percent {
+		prefix = strings.ReplaceAll(prefix, "%", "")
+		suffix = strings.ReplaceAll(suffix, "%", "") + "%"
+	}
+
+	intPart, decPart := num, ""
+	if i := strings.IndexByte(num, '.'); i >= 0 {
+		intPart, decPart = num[:i], num[i+1:]
+	}
+	minDec := strings.Count(decPart, "0")
+	maxDec := minDec + strings.Count(decPart, "#") + strings.Count(decPart, "?")
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return s
+	}
+	if percent {
+		f *= 100
+	}
+	t := strconv.FormatFloat(f, 'f', maxDec, 64)
+	if maxDec > minDec {
+		if i := strings.IndexByte(t, '.'); i >= 0 {
+			t = strings.TrimRight(t, "0")
+			if len(t)-i-1 < minDec {
+				t += strings.Repeat("0", minDec-(len(t)-i-1))
+			}
+			t = strings.TrimSuffix(t, ".")
+		}
+	}
+	if strings.Contains(intPart, ",") {
+		t = group(t)
+	}
+	return prefix + t + suffix
+}
+
+// group inserts thousands separators into the integer part of the number.
+func group(s string) string {
+	var sign string
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	intPart, rest := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, rest = s[:i], s[i:]
+	}
+	if len(intPart) <= 3 {
+		return sign + s
+	}
+	var buf strings.Builder
+	buf.WriteString(sign)
+	n := len(intPart) % 3
+	if n != 0 {
+		buf.WriteString(intPart[:n])
+	}
+	for i := n; i < len(intPart); i += 3 {
+		if buf.Len() > len(sign) {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(intPart[i : i+3])
+	}
+	buf.WriteString(rest)
+	return buf.String()
+}
+
+// literal returns the literal text of the format part: quotes and escapes are removed.
+func literal(s string) string {
+	if s == "" {
+		return s
+	}
+	var buf strings.Builder
+	var inQuote bool
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"':
+			inQuote = !inQuote
+		case inQuote:
+			buf.WriteByte(c)
+		case c == '\\' && i+1 < len(s):
+			i++
+			buf.WriteByte(s[i])
+		case c == '_' && i+1 < len(s): // space with the width of the next char
+			i++
+			buf.WriteByte(' ')
+		case c == '[' && strings.IndexByte(s[i:], ']') >= 0: // [Red], [$-409]
+			i += strings.IndexByte(s[i:], ']')
+		case c == '*' || c == ',':
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	return buf.String()
+}
+
+// IsDateFormat reports whether the number format is a date/time format:
+// a section of it has date tokens (y, d, h, s, or [h], [mm], [ss]) and no number placeholders.
+//
+// The quoted, escaped and bracketed texts are literals, as the letters of
+// the number sections, such as the " pcs" of "0 pcs".
+func IsDateFormat(format string) bool {
+	var inQuote, isDate, isNumber bool
+	for i := 0; i <= len(format); i++ {
+		if i == len(format) || (format[i] == ';' && !inQuote) {
+			if isDate && !isNumber {
+				return true
+			}
+			isDate, isNumber = false, false
+			continue
+		}
+		switch c := format[i]; {
+		case c == '"':
+			inQuote = !inQuote
+		case inQuote:
+		case c == '\\' || c == '_' || c == '*': // the next char is a literal, or a filler
+			i++
+		case c == '[':
+			if j := strings.IndexByte(format[i:], ']'); j >= 0 {
+				// [h]:mm is elapsed time, [Red] and [$-409] are not date tokens
+				if tok := strings.ToLower(format[i+1 : i+j]); tok != "" && strings.Trim(tok, "hms") == "" {
+					isDate = true
+				}
+				i += j
+			}
+		case c == '0' && isDate && i > 0 && (format[i-1] == '.' || format[i-1] == '0'):
+			// the fraction of the seconds, as in "ss.000"
+		case c == '0' || c == '#' || c == '?' || c == '@':
+			isNumber = true
+		case strings.IndexByte("yYdDhHsS", c) >= 0:
+			isDate = true
+		}
+	}
+	return false
+}
+
+// DateLayout returns the Go time layout for the date format ("yyyy-mm-dd hh:mm:ss").
+//
+// If the format is not a date format, "2006-01-02" is returned for t without clock,
+// "2006-01-02 15:04:05" otherwise.
+func DateLayout(format string, t time.Time) string {
+	if !IsDateFormat(format) {
+		if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+			return "2006-01-02"
+		}
+		return "2006-01-02 15:04:05"
+	}
+	if i := strings.IndexByte(format, ';'); i >= 0 {
+		format = format[:i]
+	}
+	hour := "15"
+	if strings.Contains(strings.ToUpper(format), "AM/PM") {
+		hour = "3"
+	}
+	var buf strings.Builder
+	var prevHour bool
+	for i := 0; i < len(format); {
+		c := format[i]
+		n := 1
+		for i+n < len(format) && lower(format[i+n]) == lower(c) {
+			n++
+		}
+		switch lower(c) {
+		case 'y':
+			if n <= 2 {
+				buf.WriteString("06")
+			} else {
+				buf.WriteString("2006")
+			}
+			prevHour = false
+		case 'm':
+			rest := strings.TrimLeft(format[i+n:], ":. ")
+			if prevHour || (n <= 2 && rest != "" && lower(rest[0]) == 's') {
+				buf.WriteString([]string{"4", "04"}[min(n, 2)-1])
+			} else {
+				buf.WriteString([]string{"1", "01", "Jan", "January", "January"}[min(n, 5)-1])
+			}
+			prevHour = false
+		case 'd':
+			buf.WriteString([]string{"2", "02", "Mon", "Monday"}[min(n, 4)-1])
+			prevHour = false
+		case 'h':
+			buf.WriteString(hour)
+			prevHour = true
+		case 's':
+			buf.WriteString([]string{"5", "05"}[min(n, 2)-1])
+			prevHour = false
+		case '"':
+			j := strings.IndexByte(format[i+1:], '"')
+			if j < 0 {
+				j = len(format) - i - 1
+			}
+			buf.WriteString(format[i+1 : i+1+j])
+			n = j + 2
+		case '\\':
+			if i+1 < len(format) {
+				buf.WriteByte(format[i+1])
+			}
+			n = 2
+		case '[':
+			if j := strings.IndexByte(format[i:], ']'); j >= 0 {
+				n = j + 1
+			}
+		case 'a':
+			if strings.HasPrefix(strings.ToUpper(format[i:]), "AM/PM") {
+				buf.WriteString("PM")
+				n = 5
+			} else {
+				buf.WriteString(format[i : i+n])
+			}
+		default:
+			buf.WriteString(format[i : i+n])
+		}
+		i += n
+	}
+	return buf.String()
+}
+
+func lower(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
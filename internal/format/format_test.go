@@ -0,0 +1,59 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package format_test
+
+import (
+	"testing"
+
+	"github.com/UNO-SOFT/spreadsheet/internal/format"
+)
+
+func TestIsDateFormat(t *testing.T) {
+	for _, tc := range []struct {
+		Format string
+		Want   bool
+	}{
+		{"", false},
+		{"General", false},
+		{"0", false},
+		{"0 pcs", false},
+		{"0.00 USD", false},
+		{"#,##0.00 \"Ft\"", false},
+		{"0.00;[Red]-0.00", false},
+		{"@", false},
+		{"0\\d", false},
+		{"\"yes\";\"yes\";\"no\"", false},
+		{"yyyy-mm-dd", true},
+		{"YYYY.MM.DD", true},
+		{"dd/mm/yy", true},
+		{"yyyy-mm-dd hh:mm:ss", true},
+		{"h:mm AM/PM", true},
+		{"mm:ss.000", true},
+		{"[h]:mm", true},
+		{"[$-409]mmmm d, yyyy", true},
+		{"[Red]yyyy-mm-dd", true},
+		{"\"on \"yyyy-mm-dd", true},
+		{"0;yyyy", true},
+	} {
+		if got := format.IsDateFormat(tc.Format); got != tc.Want {
+			t.Errorf("%q: got %t, wanted %t", tc.Format, got, tc.Want)
+		}
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	for _, tc := range []struct {
+		Number, Format, Want string
+	}{
+		{"3", "0 pcs", "3 pcs"},
+		{"1234.5", "#,##0.00 USD", "1,234.50 USD"},
+		{"0.25", "0%", "25%"},
+		{"3", "yyyy-mm-dd", "3"},
+	} {
+		if got := format.FormatNumber(tc.Number, tc.Format); got != tc.Want {
+			t.Errorf("FormatNumber(%q, %q): got %q, wanted %q", tc.Number, tc.Format, got, tc.Want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package header lays out the header rows of the columns with groups,
+// for the writers that can merge cells.
+package header
+
+import (
+	"slices"
+
+	"github.com/UNO-SOFT/spreadsheet"
+)
+
+// Cell is a cell of the header.
+type Cell struct {
+	Text  string
+	Style spreadsheet.Style
+	// ColSpan and RowSpan are the number of the columns and rows the cell spans, at least 1.
+	ColSpan, RowSpan int
+	// Covered is true for the cells covered by a spanning cell.
+	Covered bool
+}
+
+// Rows returns the header rows of the columns: the rows of the groups, then the row of the names.
+//
+// The adjacent columns' same groups span the columns, and the names of the columns
+// with fewer groups span the rows down to the last header row.
+// Rows returns nil if the columns have no names and no groups.
+func Rows(cols []spreadsheet.Column) [][]Cell {
+	var depth int
+	var hasName bool
+	for _, c := range cols {
+		depth = max(depth, len(c.Groups))
+		hasName = hasName || c.Name != ""
+	}
+	if depth == 0 && !hasName {
+		return nil
+	}
+	rows := make([][]Cell, depth+1)
+	for k := range rows {
+		rows[k] = make([]Cell, len(cols))
+	}
+	for i, c := range cols {
+		for k := range rows {
+			cell := &rows[k][i]
+			if cell.Covered {
+				continue
+			}
+			switch {
+			case k < len(c.Groups):
+				n := 1
+				for i+n < len(cols) && len(cols[i+n].Groups) > k &&
+					slices.Equal(cols[i+n].Groups[:k+1], c.Groups[:k+1]) {
+					n++
+				}
+				*cell = Cell{Text: c.Groups[k], Style: c.Header, ColSpan: n, RowSpan: 1}
+				for j := 1; j < n; j++ {
+					rows[k][i+j] = Cell{ColSpan: 1, RowSpan: 1, Covered: true}
+				}
+			case k == len(c.Groups):
+				*cell = Cell{Text: c.Name, Style: c.Header, ColSpan: 1, RowSpan: len(rows) - k}
+				for l := k + 1; l < len(rows); l++ {
+					rows[l][i] = Cell{ColSpan: 1, RowSpan: 1, Covered: true}
+				}
+			}
+		}
+	}
+	return rows
+}
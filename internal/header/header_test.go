@@ -0,0 +1,90 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package header_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/UNO-SOFT/spreadsheet"
+	"github.com/UNO-SOFT/spreadsheet/internal/header"
+)
+
+func TestRows(t *testing.T) {
+	bold := spreadsheet.Style{FontBold: true}
+	covered := header.Cell{ColSpan: 1, RowSpan: 1, Covered: true}
+	for _, tc := range []struct {
+		Name string
+		Cols []spreadsheet.Column
+		Want [][]header.Cell
+	}{
+		{Name: "empty", Cols: []spreadsheet.Column{{}, {}}},
+		{Name: "flat", Cols: []spreadsheet.Column{{Name: "a"}, {Name: "b", Header: bold}},
+			Want: [][]header.Cell{{
+				{Text: "a", ColSpan: 1, RowSpan: 1},
+				{Text: "b", Style: bold, ColSpan: 1, RowSpan: 1},
+			}},
+		},
+		{Name: "uneven",
+			Cols: []spreadsheet.Column{
+				{Name: "a", Groups: []string{"X", "Y"}, Header: bold},
+				{Name: "b", Groups: []string{"X"}},
+				{Name: "c"},
+				{Name: "d", Groups: []string{"X"}},
+			},
+			Want: [][]header.Cell{
+				{
+					{Text: "X", Style: bold, ColSpan: 2, RowSpan: 1}, covered,
+					{Text: "c", ColSpan: 1, RowSpan: 3},
+					{Text: "X", ColSpan: 1, RowSpan: 1},
+				},
+				{
+					{Text: "Y", Style: bold, ColSpan: 1, RowSpan: 1},
+					{Text: "b", ColSpan: 1, RowSpan: 2},
+					covered,
+					{Text: "d", ColSpan: 1, RowSpan: 2},
+				},
+				{
+					{Text: "a", Style: bold, ColSpan: 1, RowSpan: 1},
+					covered, covered, covered,
+				},
+			},
+		},
+		{Name: "same names",
+			Cols: []spreadsheet.Column{
+				{Name: "a", Groups: []string{"2025", "Q1"}},
+				{Name: "b", Groups: []string{"2026", "Q1"}},
+				{Name: "c", Groups: []string{"2026", "Q1"}},
+				{Name: "d", Groups: []string{"2026", "Q2"}},
+				{Name: "e", Groups: []string{"2026", "Q1"}},
+			},
+			Want: [][]header.Cell{
+				{
+					{Text: "2025", ColSpan: 1, RowSpan: 1},
+					{Text: "2026", ColSpan: 4, RowSpan: 1}, covered, covered, covered,
+				},
+				{
+					{Text: "Q1", ColSpan: 1, RowSpan: 1},
+					{Text: "Q1", ColSpan: 2, RowSpan: 1}, covered,
+					{Text: "Q2", ColSpan: 1, RowSpan: 1},
+					{Text: "Q1", ColSpan: 1, RowSpan: 1},
+				},
+				{
+					{Text: "a", ColSpan: 1, RowSpan: 1},
+					{Text: "b", ColSpan: 1, RowSpan: 1},
+					{Text: "c", ColSpan: 1, RowSpan: 1},
+					{Text: "d", ColSpan: 1, RowSpan: 1},
+					{Text: "e", ColSpan: 1, RowSpan: 1},
+				},
+			},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			if got := header.Rows(tc.Cols); !reflect.DeepEqual(got, tc.Want) {
+				t.Errorf("got\n%+v,\nwanted\n%+v", got, tc.Want)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package spool collects the output of concurrently written parts
+// (the sheets) in temporary files, and copies them to the destination
+// in the order of their creation.
+package spool
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Spool copies the finished parts to the underlying io.Writer,
+// in the order of their creation.
+type Spool struct {
+	w     io.Writer
+	parts []*Part
+	mu    sync.Mutex
+}
+
+// New returns a new Spool writing to w.
+func New(w io.Writer) *Spool { return &Spool{w: w} }
+
+// NewPart returns a new Part, backed by a zstd-compressed temporary file.
+//
+// When the part is copied, open is called with the underlying writer,
+// and the part is copied to the returned writer - this allows writing
+// separators, or creating new zip entries. A nil open means the underlying writer.
+func (sp *Spool) NewPart(open func(io.Writer) (io.Writer, error)) (*Part, error) {
+	f, err := os.CreateTemp("", "spreadsheet-spool-*")
+	if err != nil {
+		return nil, err
+	}
+	os.Remove(f.Name())
+	zw, err := zstd.NewWriter(f, zstd.WithEncoderLevel(zstd.SpeedFastest))
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	p := &Part{sp: sp, f: f, zw: zw, open: open}
+	sp.mu.Lock()
+	sp.parts = append(sp.parts, p)
+	sp.mu.Unlock()
+	return p, nil
+}
+
+// Flush copies the finished parts from the head of the queue.
+func (sp *Spool) Flush() error {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+	return sp.flush()
+}
+
+func (sp *Spool) flush() error {
+	for len(sp.parts) != 0 {
+		p := sp.parts[0]
+		p.mu.Lock()
+		finished := p.zw == nil
+		p.mu.Unlock()
+		if !finished {
+			return nil
+		}
+		sp.parts = sp.parts[1:]
+		if err := p.copyTo(sp.w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes all the unfinished parts and copies everything
+// to the underlying writer.
+func (sp *Spool) Close() error {
+	sp.mu.Lock()
+	parts := append([]*Part(nil), sp.parts...)
+	sp.mu.Unlock()
+	var errs []error
+	for _, p := range parts {
+		if err := p.finish(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := sp.Flush(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// Part is one part of the output, such as a sheet.
+type Part struct {
+	sp   *Spool
+	open func(io.Writer) (io.Writer, error)
+	f    *os.File
+	zw   *zstd.Encoder
+	mu   sync.Mutex
+}
+
+// Write to the part.
+func (p *Part) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.zw == nil {
+		return 0, os.ErrClosed
+	}
+	return p.zw.Write(b)
+}
+
+// Close the part, and copy the finished parts to the destination.
+func (p *Part) Close() error {
+	if err := p.finish(); err != nil {
+		return err
+	}
+	return p.sp.Flush()
+}
+
+func (p *Part) finish() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	zw := p.zw
+	p.zw = nil
+	if zw == nil {
+		return nil
+	}
+	return zw.Close()
+}
+
+func (p *Part) copyTo(w io.Writer) error {
+	f := p.f
+	p.f = nil
+	if f == nil {
+		return nil
+	}
+	defer f.Close()
+	if p.open != nil {
+		var err error
+		if w, err = p.open(w); err != nil {
+			return err
+		}
+	}
+	if _, err := f.Seek(0, 0); err != nil {
+		return err
+	}
+	zr, err := zstd.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+	_, err = io.Copy(w, zr)
+	return err
+}
@@ -0,0 +1,277 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package json
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/UNO-SOFT/spreadsheet"
+	"github.com/UNO-SOFT/spreadsheet/internal/spool"
+)
+
+var _ = (spreadsheet.Writer)((*JSONWriter)(nil))
+
+func init() {
+	spreadsheet.Register(spreadsheet.Format{
+		Name: "json", Extensions: []string{".json"}, MIMETypes: []string{"application/json"},
+		New: func(w io.Writer, _ spreadsheet.Options) (spreadsheet.Writer, error) {
+			return NewWriter(w), nil
+		},
+	})
+	spreadsheet.Register(spreadsheet.Format{
+		Name: "ndjson", Extensions: []string{".ndjson", ".jsonl"},
+		MIMETypes: []string{"application/x-ndjson", "application/jsonl"},
+		New: func(w io.Writer, _ spreadsheet.Options) (spreadsheet.Writer, error) {
+			return NewNDJSONWriter(w), nil
+		},
+	})
+}
+
+// JSONWriter writes the sheets as JSON.
+type JSONWriter struct {
+	w      io.Writer
+	spool  *spool.Spool
+	sheets int
+	mu     sync.Mutex
+}
+
+// NewWriter returns a spreadsheet.Writer that writes one JSON object into w,
+// with the sheet names as keys, and each sheet as an array of objects
+// keyed by the column names:
+//
+//	{"Sheet1":[{"id":1,"name":"a"},{"id":2,"name":null}]}
+//
+// This writer allows concurrent writes to separate sheets.
+func NewWriter(w io.Writer) *JSONWriter {
+	return &JSONWriter{w: w, spool: spool.New(w)}
+}
+
+// NewNDJSONWriter returns a spreadsheet.Writer that writes one line
+// of JSON object per row into w, with the sheet name in the "sheet" field
+// and the row in the "row" field:
+//
+//	{"sheet":"Sheet1","row":{"id":1,"name":"a"}}
+//
+// This writer allows concurrent writes to separate sheets,
+// the lines are written in the order of AppendRow calls.
+func NewNDJSONWriter(w io.Writer) *JSONWriter {
+	return &JSONWriter{w: w}
+}
+
+// Close the JSONWriter.
+//
+// Does not close the underlying io.Writer.
+func (jw *JSONWriter) Close() error {
+	if jw == nil {
+		return nil
+	}
+	jw.mu.Lock()
+	defer jw.mu.Unlock()
+	w, sp := jw.w, jw.spool
+	jw.w, jw.spool = nil, nil
+	if w == nil || sp == nil {
+		return nil
+	}
+	if err := sp.Close(); err != nil {
+		return err
+	}
+	var err error
+	if jw.sheets == 0 {
+		_, err = io.WriteString(w, "{}\n")
+	} else {
+		_, err = io.WriteString(w, "}\n")
+	}
+	return err
+}
+
+// NewSheet returns a new sheet, the column names are used as keys,
+// the columns without name get the column's letter (A, B, ...) as key.
+func (jw *JSONWriter) NewSheet(name string, cols []spreadsheet.Column) (spreadsheet.Sheet, error) {
+	jw.mu.Lock()
+	defer jw.mu.Unlock()
+	if jw.w == nil {
+		return nil, os.ErrClosed
+	}
+	js := &JSONSheet{Name: name, keys: make([][]byte, len(cols))}
+	for i, c := range cols {
+		k := c.Name
+		if k == "" {
+			k = spreadsheet.ColumnName(i)
+		}
+		js.keys[i], _ = json.Marshal(k)
+	}
+	nameJSON, _ := json.Marshal(name)
+	if jw.spool == nil {
+		js.w, js.mu = jw.w, &jw.mu
+		js.prefix = append(append([]byte(`{"sheet":`), nameJSON...), `,"row":`...)
+		return js, nil
+	}
+
+	part, err := jw.spool.NewPart(nil)
+	if err != nil {
+		return nil, err
+	}
+	js.part, js.w, js.mu = part, part, new(sync.Mutex)
+	if jw.sheets == 0 {
+		js.buf.WriteByte('{')
+	} else {
+		js.buf.WriteByte(',')
+	}
+	jw.sheets++
+	js.buf.Write(nameJSON)
+	js.buf.WriteString(":[")
+	if _, err = part.Write(js.buf.Bytes()); err != nil {
+		part.Close()
+		return nil, err
+	}
+	return js, nil
+}
+
+// JSONSheet is one sheet.
+type JSONSheet struct {
+	w      io.Writer
+	part   *spool.Part
+	mu     *sync.Mutex
+	Name   string
+	prefix []byte
+	keys   [][]byte
+	buf    bytes.Buffer
+	rows   int
+}
+
+// AppendRow writes the row as a JSON object.
+//
+// Numbers are written as JSON numbers (spreadsheet.Number is kept as is),
+// time.Time as RFC3339 string, and nil (or invalid sql.Null*) values as null.
+func (js *JSONSheet) AppendRow(values ...any) error {
+	js.mu.Lock()
+	defer js.mu.Unlock()
+	if js.w == nil {
+		return os.ErrClosed
+	}
+	js.buf.Reset()
+	if js.part != nil {
+		if js.rows != 0 {
+			js.buf.WriteByte(',')
+		}
+	} else {
+		js.buf.Write(js.prefix)
+	}
+	js.buf.WriteByte('{')
+	for i, v := range values {
+		if i != 0 {
+			js.buf.WriteByte(',')
+		}
+		if i < len(js.keys) {
+			js.buf.Write(js.keys[i])
+		} else {
+			js.buf.WriteByte('"')
+			js.buf.WriteString(spreadsheet.ColumnName(i))
+			js.buf.WriteByte('"')
+		}
+		js.buf.WriteByte(':')
+		if err := appendValue(&js.buf, v); err != nil {
+			return fmt.Errorf("%s[%d]: %w", js.Name, i, err)
+		}
+	}
+	js.buf.WriteByte('}')
+	if js.part == nil {
+		js.buf.WriteString("}\n")
+	}
+	js.rows++
+	_, err := js.w.Write(js.buf.Bytes())
+	return err
+}
+
+// Close the sheet.
+func (js *JSONSheet) Close() error {
+	if js == nil {
+		return nil
+	}
+	js.mu.Lock()
+	defer js.mu.Unlock()
+	part := js.part
+	js.w, js.part = nil, nil
+	if part == nil {
+		return nil
+	}
+	if _, err := part.Write([]byte{']'}); err != nil {
+		part.Close()
+		return err
+	}
+	return part.Close()
+}
+
+var rNumber = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][-+]?[0-9]+)?$`)
+
+func appendValue(buf *bytes.Buffer, v any) error {
+	if _, ok := v.(json.Marshaler); !ok {
+		if vr, ok := v.(driver.Valuer); ok {
+			var err error
+			if v, err = vr.Value(); err != nil {
+				return err
+			}
+		}
+	}
+	switch x := v.(type) {
+	case nil:
+		buf.WriteString("null")
+		return nil
+	case spreadsheet.Number:
+		if x == "" {
+			buf.WriteString("null")
+			return nil
+		}
+		if rNumber.MatchString(string(x)) {
+			buf.WriteString(string(x))
+			return nil
+		}
+		v = string(x)
+	case time.Time:
+		if x.IsZero() {
+			buf.WriteString("null")
+		} else {
+			buf.WriteByte('"')
+			buf.WriteString(x.Format(time.RFC3339Nano))
+			buf.WriteByte('"')
+		}
+		return nil
+	case float64:
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			buf.WriteString("null")
+		} else {
+			buf.WriteString(strconv.FormatFloat(x, 'g', -1, 64))
+		}
+		return nil
+	case float32:
+		if math.IsNaN(float64(x)) || math.IsInf(float64(x), 0) {
+			buf.WriteString("null")
+		} else {
+			buf.WriteString(strconv.FormatFloat(float64(x), 'g', -1, 32))
+		}
+		return nil
+	case []byte:
+		v = string(x)
+	case json.Marshaler:
+	case fmt.Stringer:
+		v = x.String()
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	buf.Write(b)
+	return nil
+}
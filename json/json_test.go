@@ -0,0 +1,120 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package json_test
+
+import (
+	"bufio"
+	"bytes"
+	"database/sql"
+	stdjson "encoding/json"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/UNO-SOFT/spreadsheet"
+	"github.com/UNO-SOFT/spreadsheet/json"
+	"github.com/UNO-SOFT/spreadsheet/spreadsheettest"
+)
+
+func TestConformance(t *testing.T) {
+	spreadsheettest.Run(t, spreadsheettest.Config{
+		New: func(w io.Writer) (spreadsheet.Writer, error) { return json.NewWriter(w), nil },
+	})
+}
+
+var (
+	testCols = []spreadsheet.Column{{Name: "amount"}, {Name: "nan"}, {Name: "inf"}, {Name: "when"}, {Name: "count"}, {}}
+	testRow  = []any{
+		spreadsheet.Number("12345678901234567890.10"), math.NaN(), float32(math.Inf(-1)),
+		time.Date(2026, 10, 19, 13, 14, 15, 0, time.FixedZone("", 2*3600)), sql.NullInt64{}, "x",
+	}
+	// testJSON is the JSON text of testRow
+	testJSON = `{"amount":12345678901234567890.10,"nan":null,"inf":null,"when":"2026-10-19T13:14:15+02:00","count":null,"F":"x"}`
+)
+
+// writeSheets writes the testRow into each sheet.
+func writeSheets(t *testing.T, w spreadsheet.Writer, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		sheet, err := w.NewSheet(name, testCols)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = sheet.AppendRow(testRow...); err != nil {
+			t.Fatal(err)
+		}
+		if err = sheet.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writeSheets(t, json.NewWriter(&buf), "a", "b")
+	if want := `{"a":[` + testJSON + `],"b":[` + testJSON + "]}\n"; buf.String() != want {
+		t.Errorf("got\n%s, wanted\n%s", buf.String(), want)
+	}
+	var got map[string][]map[string]any
+	dec := stdjson.NewDecoder(&buf)
+	dec.UseNumber()
+	if err := dec.Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || len(got["a"]) != 1 || len(got["b"]) != 1 {
+		t.Fatalf("got %+v", got)
+	}
+	if n := got["a"][0]["amount"]; n != stdjson.Number("12345678901234567890.10") {
+		t.Errorf("got amount %#v", n)
+	}
+
+	buf.Reset()
+	if err := json.NewWriter(&buf).Close(); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "{}\n" {
+		t.Errorf("got %q for no sheets, wanted {}", buf.String())
+	}
+}
+
+func TestNDJSONWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writeSheets(t, json.NewNDJSONWriter(&buf), "a", "b")
+	if want := `{"sheet":"a","row":` + testJSON + "}\n" +
+		`{"sheet":"b","row":` + testJSON + "}\n"; buf.String() != want {
+		t.Errorf("got\n%s, wanted\n%s", buf.String(), want)
+	}
+	var sheets []string
+	scanner := bufio.NewScanner(bytes.NewReader(buf.Bytes()))
+	for scanner.Scan() {
+		var line struct {
+			Row   map[string]any `json:"row"`
+			Sheet string         `json:"sheet"`
+		}
+		if err := stdjson.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("%s: %+v", scanner.Bytes(), err)
+		}
+		sheets = append(sheets, line.Sheet)
+		if line.Row["when"] != "2026-10-19T13:14:15+02:00" || line.Row["count"] != nil {
+			t.Errorf("got %+v", line.Row)
+		}
+	}
+	if want := []string{"a", "b"}; !reflect.DeepEqual(sheets, want) {
+		t.Errorf("got sheets %q, wanted %q", sheets, want)
+	}
+
+	buf.Reset()
+	if err := json.NewNDJSONWriter(&buf).Close(); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("got %q for no sheets, wanted nothing", buf.String())
+	}
+}
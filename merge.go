@@ -0,0 +1,16 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package spreadsheet
+
+// CellMerger is implemented by the Sheets that can merge cells.
+type CellMerger interface {
+	// MergeCells merges the cells of the range, such as "A2:C3":
+	// the top-left cell's value spans the range, the others are hidden.
+	//
+	// The range must be below the appended rows, the header rows included:
+	// merging the cells of an already appended row returns an error.
+	// The rows of the range that are not appended until the Sheet is closed stay empty.
+	MergeCells(ref string) error
+}
@@ -0,0 +1,234 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package spreadsheet
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"sync"
+)
+
+// MultiWriter returns a Writer that duplicates the sheets and rows
+// to all the writers, the errors are joined with errors.Join.
+//
+// This writer allows concurrent writes to separate sheets,
+// if all the underlying writers allow it.
+func MultiWriter(writers ...Writer) Writer {
+	return &multiWriter{writers: slices.Clone(writers)}
+}
+
+// PipelinedMultiWriter is like MultiWriter, but writes each target in its own goroutine,
+// through a channel buffering bufSize operations, so a slow writer does not stall the others.
+//
+// As the writes are asynchronous, the errors of AppendRow are returned
+// by a later AppendRow, or the Close of the sheet.
+// After the Close of the writer, the sheets return os.ErrClosed.
+//
+// This writer allows concurrent writes to separate sheets,
+// the calls on one sheet must not be concurrent.
+func PipelinedMultiWriter(bufSize int, writers ...Writer) Writer {
+	mw := &multiWriter{writers: slices.Clone(writers), pipes: make([]*pipe, len(writers))}
+	for i := range mw.pipes {
+		mw.pipes[i] = newPipe(bufSize)
+	}
+	return mw
+}
+
+type multiWriter struct {
+	writers []Writer
+	pipes   []*pipe
+	mu      sync.Mutex
+}
+
+func (mw *multiWriter) NewSheet(name string, cols []Column) (Sheet, error) {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+	if mw.writers == nil {
+		return nil, os.ErrClosed
+	}
+	ms := &multiSheet{sheets: make([]Sheet, len(mw.writers)), pipes: mw.pipes}
+	if mw.pipes != nil {
+		// the sheets are created in the pipes, in order with the other operations
+		ms.errs = make([]error, len(mw.writers))
+		for i, w := range mw.writers {
+			if err := mw.pipes[i].do(func() {
+				s, err := w.NewSheet(name, cols)
+				ms.mu.Lock()
+				ms.sheets[i], ms.errs[i] = s, err
+				ms.mu.Unlock()
+			}); err != nil {
+				return nil, err
+			}
+		}
+		return ms, nil
+	}
+	var errs []error
+	for i, w := range mw.writers {
+		var err error
+		if ms.sheets[i], err = w.NewSheet(name, cols); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		ms.Close()
+		return nil, err
+	}
+	return ms, nil
+}
+
+func (mw *multiWriter) Close() error {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+	writers := mw.writers
+	mw.writers = nil
+	errs := make([]error, len(writers))
+	for i, w := range writers {
+		if mw.pipes == nil {
+			errs[i] = w.Close()
+			continue
+		}
+		if err := mw.pipes[i].do(func() { errs[i] = w.Close() }); err != nil {
+			errs[i] = err
+		}
+	}
+	for _, p := range mw.pipes {
+		p.close()
+	}
+	return errors.Join(errs...)
+}
+
+type multiSheet struct {
+	sheets []Sheet
+	pipes  []*pipe
+	errs   []error
+	mu     sync.Mutex
+}
+
+func (ms *multiSheet) AppendRow(values ...any) error {
+	if ms.pipes == nil {
+		var errs []error
+		for _, s := range ms.sheets {
+			if s == nil {
+				continue
+			}
+			if err := s.AppendRow(values...); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	}
+	// the caller may reuse the slice for the next row
+	values = slices.Clone(values)
+	var closed error
+	for i, p := range ms.pipes {
+		closed = p.do(func() {
+			ms.mu.Lock()
+			s, failed := ms.sheets[i], ms.errs[i] != nil
+			ms.mu.Unlock()
+			if s == nil || failed {
+				return
+			}
+			if err := s.AppendRow(values...); err != nil {
+				ms.mu.Lock()
+				ms.errs[i] = err
+				ms.mu.Unlock()
+			}
+		})
+	}
+	// the row is written to the other targets even if one has failed
+	return errors.Join(ms.takeErrors(), closed)
+}
+
+// takeErrors returns the errors of the pipelined operations so far - each one once.
+func (ms *multiSheet) takeErrors() error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	var errs []error
+	for i, err := range ms.errs {
+		if err != nil && err != errReported {
+			errs = append(errs, err)
+			ms.errs[i] = errReported
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// errReported marks the failed targets whose error has already been returned.
+var errReported = errors.New("reported")
+
+func (ms *multiSheet) Close() error {
+	if ms.pipes == nil {
+		var errs []error
+		for i, s := range ms.sheets {
+			if s != nil {
+				errs = append(errs, s.Close())
+				ms.sheets[i] = nil
+			}
+		}
+		return errors.Join(errs...)
+	}
+	var wg sync.WaitGroup
+	closeErrs := make([]error, len(ms.pipes))
+	for i, p := range ms.pipes {
+		wg.Add(1)
+		if err := p.do(func() {
+			defer wg.Done()
+			ms.mu.Lock()
+			s := ms.sheets[i]
+			ms.sheets[i] = nil
+			ms.mu.Unlock()
+			if s != nil {
+				closeErrs[i] = s.Close()
+			}
+		}); err != nil {
+			closeErrs[i] = err
+			wg.Done()
+		}
+	}
+	wg.Wait()
+	return errors.Join(ms.takeErrors(), errors.Join(closeErrs...))
+}
+
+// pipe executes the functions in order, in its own goroutine.
+type pipe struct {
+	ch     chan func()
+	done   chan struct{}
+	mu     sync.Mutex
+	closed bool
+}
+
+func newPipe(bufSize int) *pipe {
+	p := &pipe{ch: make(chan func(), max(0, bufSize)), done: make(chan struct{})}
+	go func() {
+		defer close(p.done)
+		for f := range p.ch {
+			f()
+		}
+	}()
+	return p
+}
+
+// do queues f, or returns os.ErrClosed if the pipe is closed.
+func (p *pipe) do(f func()) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return os.ErrClosed
+	}
+	p.ch <- f
+	return nil
+}
+
+// close the pipe and wait for the remaining functions to finish.
+func (p *pipe) close() {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.ch)
+	}
+	p.mu.Unlock()
+	<-p.done
+}
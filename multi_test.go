@@ -0,0 +1,180 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package spreadsheet_test
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/UNO-SOFT/spreadsheet"
+)
+
+// memWriter records the sheets and rows in memory.
+type memWriter struct {
+	sheets map[string]*memSheet
+	// err is returned by AppendRow of the sheets
+	err error
+	// maxRows is the maximum number of rows of a sheet, if not zero
+	maxRows int
+	closed  bool
+	mu      sync.Mutex
+}
+
+type memSheet struct {
+	w      *memWriter
+	Cols   []spreadsheet.Column
+	Rows   [][]any
+	closed bool
+}
+
+func (w *memWriter) NewSheet(name string, cols []spreadsheet.Column) (spreadsheet.Sheet, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return nil, os.ErrClosed
+	}
+	if w.sheets == nil {
+		w.sheets = make(map[string]*memSheet)
+	}
+	s := &memSheet{w: w, Cols: cols}
+	w.sheets[name] = s
+	return s, nil
+}
+
+func (w *memWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closed = true
+	return nil
+}
+
+func (s *memSheet) AppendRow(values ...any) error {
+	s.w.mu.Lock()
+	defer s.w.mu.Unlock()
+	if s.w.err != nil {
+		return s.w.err
+	}
+	if s.w.maxRows != 0 && len(s.Rows) >= s.w.maxRows {
+		return spreadsheet.ErrTooManyRows
+	}
+	s.Rows = append(s.Rows, slices.Clone(values))
+	return nil
+}
+
+func (s *memSheet) Close() error {
+	s.w.mu.Lock()
+	defer s.w.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+type numbered struct{ N int }
+
+func TestMultiWriter(t *testing.T) {
+	want := [][]any{{1}, {2}, {3}, {4}, {5}}
+	for name, newWriter := range map[string]func(...spreadsheet.Writer) spreadsheet.Writer{
+		"MultiWriter": spreadsheet.MultiWriter,
+		"PipelinedMultiWriter": func(writers ...spreadsheet.Writer) spreadsheet.Writer {
+			return spreadsheet.PipelinedMultiWriter(2, writers...)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			targets := []*memWriter{{}, {}}
+			w := newWriter(targets[0], targets[1])
+			sheet, err := w.NewSheet("Numbers", spreadsheet.ColumnsOf[numbered]())
+			if err != nil {
+				t.Fatal(err)
+			}
+			// WriteStructSlice reuses the values slice for every row
+			if err = spreadsheet.WriteStructSlice(sheet, []numbered{{1}, {2}, {3}, {4}, {5}}); err != nil {
+				t.Fatal(err)
+			}
+			if err = sheet.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if err = w.Close(); err != nil {
+				t.Fatal(err)
+			}
+			for i, target := range targets {
+				s := target.sheets["Numbers"]
+				if s == nil {
+					t.Fatalf("%d. no sheet", i)
+				}
+				if !reflect.DeepEqual(s.Rows, want) {
+					t.Errorf("%d. got %v, wanted %v", i, s.Rows, want)
+				}
+				if !s.closed || !target.closed {
+					t.Errorf("%d. not closed", i)
+				}
+			}
+			if _, err = w.NewSheet("Late", nil); err == nil {
+				t.Error("NewSheet succeeded after Close")
+			}
+		})
+	}
+}
+
+func TestMultiWriterErrors(t *testing.T) {
+	errFull := errors.New("full")
+	for name, newWriter := range map[string]func(...spreadsheet.Writer) spreadsheet.Writer{
+		"MultiWriter": spreadsheet.MultiWriter,
+		"PipelinedMultiWriter": func(writers ...spreadsheet.Writer) spreadsheet.Writer {
+			return spreadsheet.PipelinedMultiWriter(0, writers...)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			good, bad := &memWriter{}, &memWriter{err: errFull}
+			w := newWriter(good, bad)
+			defer w.Close()
+			sheet, err := w.NewSheet("S", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the pipelined errors are returned later
+			var errs []error
+			for i := range 3 {
+				errs = append(errs, sheet.AppendRow(i))
+			}
+			errs = append(errs, sheet.Close())
+			if err = errors.Join(errs...); !errors.Is(err, errFull) {
+				t.Errorf("got %+v, wanted %v", err, errFull)
+			}
+			if got := len(good.sheets["S"].Rows); got != 3 {
+				t.Errorf("the good writer got %d rows, wanted 3", got)
+			}
+		})
+	}
+}
+
+func TestPipelinedMultiWriterClosed(t *testing.T) {
+	target := &memWriter{}
+	w := spreadsheet.PipelinedMultiWriter(1, target)
+	sheet, err := w.NewSheet("S", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = sheet.AppendRow(1); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = sheet.AppendRow(2); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("AppendRow after Close: got %+v, wanted %v", err, os.ErrClosed)
+	}
+	if err = sheet.Close(); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("Close after Close: got %+v, wanted %v", err, os.ErrClosed)
+	}
+	if err = w.Close(); err != nil {
+		t.Errorf("second Close: %+v", err)
+	}
+	if got := target.sheets["S"].Rows; !reflect.DeepEqual(got, [][]any{{1}}) {
+		t.Errorf("got %v", got)
+	}
+}
@@ -0,0 +1,344 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ods
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/UNO-SOFT/spreadsheet"
+)
+
+var _ = (spreadsheet.ConditionalFormatter)((*ODSSheet)(nil))
+
+// conditionalFormat is a conditional format of the sheet's range.
+type conditionalFormat struct {
+	// style is the name of the applied common style
+	style string
+	cellRange
+	cf spreadsheet.ConditionalFormat
+}
+
+// cellRange is a range of cells: first and last are the top-left and bottom-right cells.
+type cellRange struct{ first, last cellRef }
+
+func (r cellRange) String() string { return r.first.String() + ":" + r.last.String() }
+
+func (r cellRange) covers(col, row int) bool {
+	return r.first.col <= col && col <= r.last.col && r.first.row <= row && row <= r.last.row
+}
+
+func (r cellRange) overlaps(other cellRange) bool {
+	return r.first.col <= other.last.col && other.first.col <= r.last.col &&
+		r.first.row <= other.last.row && other.first.row <= r.last.row
+}
+
+// cellRef is a cell's zero-based column and one-based row.
+type cellRef struct{ col, row int }
+
+func (c cellRef) String() string { return spreadsheet.ColumnName(c.col) + strconv.Itoa(c.row) }
+
+// AddConditionalFormat adds the conditional format to the cells of the range.
+//
+// ODF 1.2 output uses the calcext:conditional-formats of LibreOffice.
+// ODF 1.3 output uses style:map in the styles of the cells appended after this call,
+// and ignores the color scales and data bars, as the standard can not express them.
+func (ods *ODSSheet) AddConditionalFormat(ref string, cf spreadsheet.ConditionalFormat) error {
+	if err := cf.Check(); err != nil {
+		return err
+	}
+	r, err := parseRange(ref)
+	if err != nil {
+		return err
+	}
+	ods.mu.Lock()
+	defer ods.mu.Unlock()
+	ow := ods.ow
+	if ow == nil {
+		return os.ErrClosed
+	}
+	if !ow.extended() && cf.Type != spreadsheet.ConditionCellValue && cf.Type != spreadsheet.ConditionFormula {
+		return nil
+	}
+	c := conditionalFormat{cellRange: r, cf: cf}
+	if cf.Type == spreadsheet.ConditionCellValue || cf.Type == spreadsheet.ConditionFormula {
+		c.style = ow.getConditionStyleName(cf.Style)
+	}
+	ods.conditions = append(ods.conditions, c)
+	clear(ods.cellStyles)
+	return nil
+}
+
+// getConditionStyleName returns the name of the common style applied by the conditions.
+func (ow *ODSWriter) getConditionStyleName(style spreadsheet.Style) string {
+	props := styleProperties(spreadsheet.Style{
+		FontBold: style.FontBold, FontColor: style.FontColor, BackgroundColor: style.BackgroundColor,
+	})
+	hsh := fnv.New32()
+	hsh.Write([]byte(props))
+	k := fmt.Sprintf("cf-%d", hsh.Sum32())
+	ow.stylesMu.Lock()
+	defer ow.stylesMu.Unlock()
+	if _, ok := ow.conditionStyles[k]; ok {
+		return k
+	}
+	if ow.conditionStyles == nil {
+		ow.conditionStyles = make(map[string]string, 1)
+	}
+	ow.conditionStyles[k] = `<style:style style:name="` + k + `" style:family="table-cell">` + props + `</style:style>`
+	return k
+}
+
+// cellStyle returns the name of the cell's style with the style:map of the conditions
+// covering the cell, or "" if there is no such condition.
+//
+// Used only with ODF 1.3, must be called with ods.mu held.
+func (ods *ODSSheet) cellStyle(col, row int) string {
+	var key []byte
+	for i, c := range ods.conditions {
+		if c.covers(col, row) {
+			key = strconv.AppendInt(append(key, ','), int64(i), 10)
+		}
+	}
+	if key == nil {
+		return ""
+	}
+	key = strconv.AppendInt(append(key, '/'), int64(col), 10)
+	if s, ok := ods.cellStyles[string(key)]; ok {
+		return s
+	}
+	var props string
+	if col < len(ods.columnStyles) {
+		props = styleProperties(ods.columnStyles[col])
+	}
+	for _, c := range ods.conditions {
+		if c.covers(col, row) {
+			props += `<style:map style:condition="` + attrEscape(styleCondition(c.cf)) +
+				`" style:apply-style-name="` + c.style +
+				`" style:base-cell-address="` + attrEscape(sheetRef(ods.Name)+"."+c.first.String()) + `"/>`
+		}
+	}
+	s := ods.ow.addStyle(props)
+	if ods.cellStyles == nil {
+		ods.cellStyles = make(map[string]string)
+	}
+	ods.cellStyles[string(key)] = s
+	return s
+}
+
+// styleCondition returns the style:condition of the ConditionCellValue or ConditionFormula.
+func styleCondition(cf spreadsheet.ConditionalFormat) string {
+	if cf.Type == spreadsheet.ConditionFormula {
+		return "is-true-formula(" + odfFormula(cf.Formula) + ")"
+	}
+	switch cf.Operator {
+	case spreadsheet.OperatorBetween:
+		return "cell-content-is-between(" + odfFormula(cf.Value) + "," + odfFormula(cf.Value2) + ")"
+	case spreadsheet.OperatorNotBetween:
+		return "cell-content-is-not-between(" + odfFormula(cf.Value) + "," + odfFormula(cf.Value2) + ")"
+	case spreadsheet.OperatorNotEqual:
+		return "cell-content()!=" + odfFormula(cf.Value)
+	default:
+		return "cell-content()" + cf.Operator.String() + odfFormula(cf.Value)
+	}
+}
+
+// calcextCondition returns the calcext:value of the ConditionCellValue or ConditionFormula.
+func calcextCondition(cf spreadsheet.ConditionalFormat) string {
+	if cf.Type == spreadsheet.ConditionFormula {
+		return "formula-is(" + odfFormula(cf.Formula) + ")"
+	}
+	switch cf.Operator {
+	case spreadsheet.OperatorBetween:
+		return "between(" + odfFormula(cf.Value) + "," + odfFormula(cf.Value2) + ")"
+	case spreadsheet.OperatorNotBetween:
+		return "not-between(" + odfFormula(cf.Value) + "," + odfFormula(cf.Value2) + ")"
+	case spreadsheet.OperatorNotEqual:
+		return "!=" + odfFormula(cf.Value)
+	default:
+		return cf.Operator.String() + odfFormula(cf.Value)
+	}
+}
+
+// conditionalFormats returns the calcext:conditional-formats of the sheet,
+// or "" if there are none or the extensions are not used.
+func (ods *ODSSheet) conditionalFormats() string {
+	if len(ods.conditions) == 0 || !ods.ow.extended() {
+		return ""
+	}
+	sheet := sheetRef(ods.Name) + "."
+	var buf strings.Builder
+	buf.WriteString(`<calcext:conditional-formats>`)
+	for _, c := range ods.conditions {
+		buf.WriteString(`<calcext:conditional-format calcext:target-range-address="` +
+			attrEscape(sheet+c.first.String()+":"+sheet+c.last.String()) + `">`)
+		switch c.cf.Type {
+		case spreadsheet.ConditionColorScale:
+			buf.WriteString(`<calcext:color-scale>`)
+			buf.WriteString(`<calcext:color-scale-entry calcext:value="0" calcext:type="minimum" calcext:color="` + attrEscape(c.cf.MinColor) + `"/>`)
+			if c.cf.MidColor != "" {
+				buf.WriteString(`<calcext:color-scale-entry calcext:value="50" calcext:type="percentile" calcext:color="` + attrEscape(c.cf.MidColor) + `"/>`)
+			}
+			buf.WriteString(`<calcext:color-scale-entry calcext:value="0" calcext:type="maximum" calcext:color="` + attrEscape(c.cf.MaxColor) + `"/>`)
+			buf.WriteString(`</calcext:color-scale>`)
+		case spreadsheet.ConditionDataBar:
+			buf.WriteString(`<calcext:data-bar calcext:max-length="100" calcext:negative-color="#ff0000" calcext:positive-color="` +
+				attrEscape(c.cf.BarColor) + `" calcext:axis-color="#000000">`)
+			buf.WriteString(`<calcext:formatting-entry calcext:value="0" calcext:type="auto-minimum"/>`)
+			buf.WriteString(`<calcext:formatting-entry calcext:value="0" calcext:type="auto-maximum"/>`)
+			buf.WriteString(`</calcext:data-bar>`)
+		default:
+			buf.WriteString(`<calcext:condition calcext:apply-style-name="` + c.style +
+				`" calcext:value="` + attrEscape(calcextCondition(c.cf)) +
+				`" calcext:base-cell-address="` + attrEscape(sheet+c.first.String()) + `"/>`)
+		}
+		buf.WriteString(`</calcext:conditional-format>`)
+	}
+	buf.WriteString(`</calcext:conditional-formats>`)
+	return buf.String()
+}
+
+var rCell = regexp.MustCompile(`^\$?([A-Za-z]{1,3})\$?([0-9]+)$`)
+
+// parseCell parses the A1 style cell reference.
+func parseCell(ref string) (cellRef, error) {
+	m := rCell.FindStringSubmatch(ref)
+	if m == nil {
+		return cellRef{}, fmt.Errorf("%q is not a cell reference", ref)
+	}
+	var c cellRef
+	for _, r := range strings.ToUpper(m[1]) {
+		c.col = c.col*26 + int(r-'A'+1)
+	}
+	c.col--
+	var err error
+	if c.row, err = strconv.Atoi(m[2]); err != nil || c.row == 0 {
+		return c, fmt.Errorf("%q is not a cell reference", ref)
+	}
+	return c, nil
+}
+
+// parseRange parses the "A1:B10" (or "A1") range.
+func parseRange(ref string) (cellRange, error) {
+	var r cellRange
+	a, b, ok := strings.Cut(ref, ":")
+	if !ok {
+		b = a
+	}
+	var err error
+	if r.first, err = parseCell(a); err != nil {
+		return r, err
+	}
+	if r.last, err = parseCell(b); err != nil {
+		return r, err
+	}
+	if r.last.col < r.first.col {
+		r.first.col, r.last.col = r.last.col, r.first.col
+	}
+	if r.last.row < r.first.row {
+		r.first.row, r.last.row = r.last.row, r.first.row
+	}
+	return r, nil
+}
+
+// odfFormula converts the A1 style formula to OpenFormula:
+// the references into the [.A1] form, the argument separators into semicolons.
+func odfFormula(f string) string {
+	var buf strings.Builder
+	for i := 0; i < len(f); {
+		switch c := f[i]; {
+		case c == '"':
+			j := i + 1
+			for ; j < len(f); j++ {
+				if f[j] == '"' {
+					if j+1 < len(f) && f[j+1] == '"' {
+						j++
+						continue
+					}
+					j++
+					break
+				}
+			}
+			buf.WriteString(f[i:min(j, len(f))])
+			i = j
+		case c == ',':
+			buf.WriteByte(';')
+			i++
+		case c == '\'' || c == '$' || isNameByte(c):
+			j := scanReference(&buf, f, i)
+			i = j
+		default:
+			buf.WriteByte(c)
+			i++
+		}
+	}
+	return buf.String()
+}
+
+// scanReference converts the reference (or name) at f[i:] into buf, and returns its end.
+func scanReference(buf *strings.Builder, f string, i int) int {
+	name := func(i int) int {
+		for i < len(f) && (f[i] == '$' || isNameByte(f[i])) {
+			i++
+		}
+		return i
+	}
+	var sheet string
+	j := i
+	if f[i] == '\'' {
+		for j = i + 1; j < len(f); j++ {
+			if f[j] == '\'' {
+				if j+1 < len(f) && f[j+1] == '\'' {
+					j++
+					continue
+				}
+				break
+			}
+		}
+		if j+1 >= len(f) || f[j+1] != '!' {
+			buf.WriteString(f[i:min(j+1, len(f))])
+			return j + 1
+		}
+		sheet = strings.ReplaceAll(f[i+1:j], "''", "'")
+		j += 2
+	} else {
+		j = name(i)
+		if j < len(f) && f[j] == '!' {
+			sheet = f[i:j]
+			j++
+		} else if j < len(f) && f[j] == '(' || !rCell.MatchString(f[i:j]) {
+			buf.WriteString(f[i:j]) // function or name
+			return j
+		} else {
+			j = i
+		}
+	}
+	k := name(j)
+	if !rCell.MatchString(f[j:k]) {
+		buf.WriteString(f[i:k])
+		return k
+	}
+	buf.WriteByte('[')
+	if sheet != "" {
+		buf.WriteString("$" + sheetRef(sheet))
+	}
+	buf.WriteString("." + f[j:k])
+	if k+1 < len(f) && f[k] == ':' {
+		if l := name(k + 1); rCell.MatchString(f[k+1 : l]) {
+			buf.WriteString(":." + f[k+1:l])
+			k = l
+		}
+	}
+	buf.WriteByte(']')
+	return k
+}
+
+func isNameByte(c byte) bool {
+	return 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '_' || c == '.'
+}
@@ -8,70 +8,82 @@ package ods
 import "strings"
 
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:2
-import "encoding/xml"
+import "encoding/base64"
 
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:3
-import "time"
+import "crypto/sha256"
 
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:4
-import "fmt"
+import "encoding/xml"
 
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:5
+import "time"
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:6
+import "fmt"
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:7
 import "github.com/UNO-SOFT/spreadsheet"
 
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:8
+import "github.com/UNO-SOFT/spreadsheet/internal/format"
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:9
+import "github.com/UNO-SOFT/spreadsheet/internal/header"
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:12
 import (
 	qtio422016 "io"
 
 	qt422016 "github.com/valyala/quicktemplate"
 )
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:8
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:12
 var (
 	_ = qtio422016.Copy
 	_ = qt422016.AcquireByteBuffer
 )
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:8
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:12
 func StreamXML(qw422016 *qt422016.Writer, s string) {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:10
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:14
 	var buf strings.Builder
 	_ = xml.EscapeText(&buf, []byte(s))
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:13
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:17
 	qw422016.N().S(buf.String())
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:14
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:18
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:14
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:18
 func WriteXML(qq422016 qtio422016.Writer, s string) {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:14
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:18
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:14
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:18
 	StreamXML(qw422016, s)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:14
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:18
 	qt422016.ReleaseWriter(qw422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:14
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:18
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:14
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:18
 func XML(s string) string {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:14
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:18
 	qb422016 := qt422016.AcquireByteBuffer()
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:14
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:18
 	WriteXML(qb422016, s)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:14
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:18
 	qs422016 := string(qb422016.B)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:14
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:18
 	qt422016.ReleaseByteBuffer(qb422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:14
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:18
 	return qs422016
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:14
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:18
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:15
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:19
 func streamgetDateValue(qw422016 *qt422016.Writer, v interface{}) {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:17
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:21
 	var buf strings.Builder
 	if x, ok := v.(time.Time); !ok || x.IsZero() {
 		buf.WriteString("1899-12-30")
@@ -80,40 +92,40 @@ func streamgetDateValue(qw422016 *qt422016.Writer, v interface{}) {
 		buf.WriteString(x.Format(time.RFC3339))
 	}
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:24
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:28
 	qw422016.N().S(buf.String())
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:25
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:29
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:25
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:29
 func writegetDateValue(qq422016 qtio422016.Writer, v interface{}) {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:25
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:29
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:25
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:29
 	streamgetDateValue(qw422016, v)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:25
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:29
 	qt422016.ReleaseWriter(qw422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:25
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:29
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:25
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:29
 func getDateValue(v interface{}) string {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:25
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:29
 	qb422016 := qt422016.AcquireByteBuffer()
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:25
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:29
 	writegetDateValue(qb422016, v)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:25
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:29
 	qs422016 := string(qb422016.B)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:25
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:29
 	qt422016.ReleaseByteBuffer(qb422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:25
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:29
 	return qs422016
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:25
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:29
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:26
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:30
 func streamgetValue(qw422016 *qt422016.Writer, v interface{}) {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:28
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:32
 	var buf strings.Builder
 	switch x := v.(type) {
 	case time.Time:
@@ -134,40 +146,40 @@ func streamgetValue(qw422016 *qt422016.Writer, v interface{}) {
 		_ = xml.EscapeText(&buf, []byte(fmt.Sprintf("%v", v)))
 	}
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:47
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:51
 	qw422016.N().S(buf.String())
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:48
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:52
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:48
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:52
 func writegetValue(qq422016 qtio422016.Writer, v interface{}) {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:48
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:52
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:48
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:52
 	streamgetValue(qw422016, v)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:48
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:52
 	qt422016.ReleaseWriter(qw422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:48
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:52
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:48
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:52
 func getValue(v interface{}) string {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:48
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:52
 	qb422016 := qt422016.AcquireByteBuffer()
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:48
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:52
 	writegetValue(qb422016, v)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:48
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:52
 	qs422016 := string(qb422016.B)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:48
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:52
 	qt422016.ReleaseByteBuffer(qb422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:48
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:52
 	return qs422016
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:48
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:52
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:49
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:53
 func streamgetText(qw422016 *qt422016.Writer, v interface{}) {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:51
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:55
 	var buf strings.Builder
 	switch x := v.(type) {
 	case time.Time:
@@ -186,512 +198,1196 @@ func streamgetText(qw422016 *qt422016.Writer, v interface{}) {
 		_ = xml.EscapeText(&buf, []byte(fmt.Sprintf("%v", v)))
 	}
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:68
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:72
 	qw422016.N().S(buf.String())
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:69
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:73
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:69
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:73
 func writegetText(qq422016 qtio422016.Writer, v interface{}) {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:69
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:73
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:69
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:73
 	streamgetText(qw422016, v)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:69
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:73
 	qt422016.ReleaseWriter(qw422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:69
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:73
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:69
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:73
 func getText(v interface{}) string {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:69
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:73
 	qb422016 := qt422016.AcquireByteBuffer()
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:69
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:73
 	writegetText(qb422016, v)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:69
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:73
 	qs422016 := string(qb422016.B)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:69
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:73
 	qt422016.ReleaseByteBuffer(qb422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:69
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:73
 	return qs422016
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:69
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:73
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:72
-func StreamBeginSpreadsheet(qw422016 *qt422016.Writer) {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:72
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:76
+func (ow *ODSWriter) StreamBeginSpreadsheet(qw422016 *qt422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:76
 	qw422016.N().S(`<?xml version="1.0" encoding="UTF-8"?>
-<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:draw="urn:oasis:names:tc:opendocument:xmlns:drawing:1.0" xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0" xmlns:xlink="http://www.w3.org/1999/xlink" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:meta="urn:oasis:names:tc:opendocument:xmlns:meta:1.0" xmlns:number="urn:oasis:names:tc:opendocument:xmlns:datastyle:1.0" xmlns:svg="urn:oasis:names:tc:opendocument:xmlns:svg-compatible:1.0" xmlns:chart="urn:oasis:names:tc:opendocument:xmlns:chart:1.0" xmlns:dr3d="urn:oasis:names:tc:opendocument:xmlns:dr3d:1.0" xmlns:config="urn:oasis:names:tc:opendocument:xmlns:config:1.0" xmlns:math="http://www.w3.org/1998/Math/MathML" xmlns:form="urn:oasis:names:tc:opendocument:xmlns:form:1.0" xmlns:script="urn:oasis:names:tc:opendocument:xmlns:script:1.0" xmlns:ooo="http://openoffice.org/2004/office" xmlns:ooow="http://openoffice.org/2004/writer" xmlns:oooc="http://openoffice.org/2004/calc" xmlns:tableooo="http://openoffice.org/2009/table" xmlns:of="urn:oasis:names:tc:opendocument:xmlns:of:1.2" xmlns:dom="http://www.w3.org/2001/xml-events" xmlns:xforms="http://www.w3.org/2002/xforms" xmlns:xsd="http://www.w3.org/2001/XMLSchema" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns:gnm="http://www.gnumeric.org/odf-extension/1.0" xmlns:css3t="http://www.w3.org/TR/css3-text/" xmlns:loext="urn:org:documentfoundation:names:experimental:office:xmlns:loext:1.0" xmlns:calcext="urn:org:documentfoundation:names:experimental:calc:xmlns:calcext:1.0" office:version="1.2">
+<office:document-content`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:77
+	ow.streamnamespaces(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:77
+	qw422016.N().S(` office:version="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:77
+	qw422016.E().S(ow.version)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:77
+	qw422016.N().S(`">
   <office:scripts/>
   <office:font-face-decls/>
   <office:automatic-styles/>
-  <office:body>
-    <office:spreadsheet>
-      <table:calculation-settings table:null-year="1930" table:automatic-find-labels="false" table:case-sensitive="false" table:precision-as-shown="false" table:search-criteria-must-apply-to-whole-cell="true" table:use-regular-expressions="false" table:use-wildcards="false">
-        <table:null-date table:date-value="1899-12-30" table:value-type="date"/>
-        <table:iteration table:maximum-difference="0.001" table:status="enable" table:steps="100"/>
-      </table:calculation-settings>
 `)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:83
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:81
+	ow.StreamBeginBody(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:81
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:83
-func WriteBeginSpreadsheet(qq422016 qtio422016.Writer) {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:83
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:81
+func (ow *ODSWriter) WriteBeginSpreadsheet(qq422016 qtio422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:81
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:83
-	StreamBeginSpreadsheet(qw422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:83
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:81
+	ow.StreamBeginSpreadsheet(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:81
 	qt422016.ReleaseWriter(qw422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:83
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:81
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:83
-func BeginSpreadsheet() string {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:83
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:81
+func (ow *ODSWriter) BeginSpreadsheet() string {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:81
 	qb422016 := qt422016.AcquireByteBuffer()
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:83
-	WriteBeginSpreadsheet(qb422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:83
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:81
+	ow.WriteBeginSpreadsheet(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:81
 	qs422016 := string(qb422016.B)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:83
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:81
 	qt422016.ReleaseByteBuffer(qb422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:83
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:81
 	return qs422016
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:83
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:81
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:85
-func (ow *ODSWriter) StreamBeginSheet(qw422016 *qt422016.Writer, name string, cols []spreadsheet.Column) {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:85
-	qw422016.N().S(`<table:table table:name="`)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:85
-	StreamXML(qw422016, name)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:85
-	qw422016.N().S(`" table:print="true">`)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:86
-	var hasHeader bool
-
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:87
-	for _, c := range cols {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:87
-		qw422016.N().S(`<table:table-column table:style-name="`)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:87
-		qw422016.E().S(ow.getStyleName(c.Column))
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:87
-		qw422016.N().S(`" />`)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:87
-		if c.Name != "" {
-			hasHeader = true
-		}
-
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:88
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:83
+func (ow *ODSWriter) StreamBeginBody(qw422016 *qt422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:83
+	qw422016.N().S(`  <office:body>
+    <office:spreadsheet`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:84
+	if ow.workbookProtection != nil {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:84
+		qw422016.N().S(` table:structure-protected="true"`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:84
+		streamprotectionKey(qw422016, *ow.workbookProtection)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:84
 	}
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:84
+	qw422016.N().S(`>
+      <table:calculation-settings table:null-year="1930" table:automatic-find-labels="false" table:case-sensitive="false" table:precision-as-shown="false" table:search-criteria-must-apply-to-whole-cell="true" table:use-regular-expressions="false" table:use-wildcards="false">
+        <table:null-date table:date-value="1899-12-30" table:value-type="date"/>
+        <table:iteration table:maximum-difference="0.001" table:status="enable" table:steps="100"/>
+      </table:calculation-settings>`)
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:89
-	if hasHeader {
+	if len(ow.validations) != 0 {
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:89
-		qw422016.N().S(`<table:table-row>`)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:90
-		for _, c := range cols {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:90
-			qw422016.N().S(`<table:table-cell office:value-type="string" table:style-name="`)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:90
-			qw422016.N().S(ow.getStyleName(c.Header))
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:90
-			qw422016.N().S(`"><text:p>`)
+		qw422016.N().S(`
+      <table:content-validations>`)
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:90
-			StreamXML(qw422016, c.Name)
+		for _, v := range ow.validations {
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:90
-			qw422016.N().S(`</text:p></table:table-cell>`)
+			qw422016.N().S(`
+        `)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:91
+			qw422016.N().S(v)
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:91
 		}
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:91
-		qw422016.N().S(`</table:table-row>`)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:92
+		qw422016.N().S(`
+      </table:content-validations>`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:93
 	}
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:92
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:93
 	qw422016.N().S(`
 `)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:93
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:94
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:93
-func (ow *ODSWriter) WriteBeginSheet(qq422016 qtio422016.Writer, name string, cols []spreadsheet.Column) {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:93
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:94
+func (ow *ODSWriter) WriteBeginBody(qq422016 qtio422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:94
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:93
-	ow.StreamBeginSheet(qw422016, name, cols)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:93
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:94
+	ow.StreamBeginBody(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:94
 	qt422016.ReleaseWriter(qw422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:93
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:94
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:93
-func (ow *ODSWriter) BeginSheet(name string, cols []spreadsheet.Column) string {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:93
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:94
+func (ow *ODSWriter) BeginBody() string {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:94
 	qb422016 := qt422016.AcquireByteBuffer()
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:93
-	ow.WriteBeginSheet(qb422016, name, cols)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:93
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:94
+	ow.WriteBeginBody(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:94
 	qs422016 := string(qb422016.B)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:93
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:94
 	qt422016.ReleaseByteBuffer(qb422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:93
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:94
 	return qs422016
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:93
-}
-
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:95
-func StreamEndSheet(qw422016 *qt422016.Writer) {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:95
-	qw422016.N().S(`
-      </table:table>
-`)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:97
-}
-
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:97
-func WriteEndSheet(qq422016 qtio422016.Writer) {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:97
-	qw422016 := qt422016.AcquireWriter(qq422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:97
-	StreamEndSheet(qw422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:97
-	qt422016.ReleaseWriter(qw422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:97
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:94
 }
 
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:96
+func (ow *ODSWriter) StreamBeginSheet(qw422016 *qt422016.Writer, name string, cols []spreadsheet.Column) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:96
+	qw422016.N().S(`<table:table table:name="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:96
+	StreamXML(qw422016, name)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:96
+	qw422016.N().S(`" table:print="true"`)
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:97
-func EndSheet() string {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:97
-	qb422016 := qt422016.AcquireByteBuffer()
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:97
-	WriteEndSheet(qb422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:97
-	qs422016 := string(qb422016.B)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:97
-	qt422016.ReleaseByteBuffer(qb422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:97
-	return qs422016
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:97
-}
+	p, protected := ow.protections[name]
 
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:98
+	if protected {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:98
+		qw422016.N().S(` table:protected="true"`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:98
+		streamprotectionKey(qw422016, p.Password)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:98
+	}
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:98
+	qw422016.N().S(`>`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:99
+	if protected && ow.extended() {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:99
+		qw422016.N().S(`<loext:table-protection loext:select-protected-cells="true" loext:select-unprotected-cells="true"`)
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:100
-func StreamRow(qw422016 *qt422016.Writer, values ...interface{}) {
+		if p.AllowInsertColumns {
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:100
-	qw422016.N().S(`<table:table-row>`)
+			qw422016.N().S(` loext:insert-columns="true"`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:100
+		}
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:101
-	for _, v := range values {
+		if p.AllowInsertRows {
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:101
-		typ := getValueType(v)
-
+			qw422016.N().S(` loext:insert-rows="true"`)
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:101
-		qw422016.N().S(`
-	<table:table-cell `)
+		}
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:102
+		if p.AllowDeleteColumns {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:102
+			qw422016.N().S(` loext:delete-columns="true"`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:102
+		}
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:103
-		if typ == FloatType {
+		if p.AllowDeleteRows {
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:103
-			qw422016.N().S(` office:value-type="float" office:value="`)
+			qw422016.N().S(` loext:delete-rows="true"`)
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:103
-			qw422016.N().S(fmt.Sprintf("%v", v))
+		}
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:103
-			qw422016.N().S(`" calcext:value-type="float"`)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:104
-		} else if false && typ == DateType {
+		qw422016.N().S(`/>`)
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:104
-			qw422016.N().S(` office:value-type="date" office:date-value="`)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:104
-			streamgetDateValue(qw422016, v)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:104
-			qw422016.N().S(`" calcext:value-type="date"`)
+	}
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:105
-		} else {
+	for _, c := range cols {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:105
+		qw422016.N().S(`<table:table-column`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:105
+		if s := ow.getStyleName(c.Column); s != "" {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:105
+			qw422016.N().S(` table:default-cell-style-name="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:105
+			qw422016.E().S(s)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:105
+			qw422016.N().S(`"`)
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:105
-			qw422016.N().S(` office:value-type="string"`)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:106
 		}
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:105
+		qw422016.N().S(` />`)
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:106
-		qw422016.N().S(` ><text:p>`)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:106
-		text := getText(v)
-
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:107
-		if typ == LinkType {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:107
-			qw422016.N().S(`<text:a xlink:href="`)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:107
-			qw422016.N().S(text)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:107
-			qw422016.N().S(`">`)
+	}
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:107
-			qw422016.N().S(text)
+	for _, row := range header.Rows(cols) {
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:107
-			qw422016.N().S(`</text:a>`)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:108
-		} else {
+		qw422016.N().S(`<table:table-row>`)
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:108
-			qw422016.N().S(text)
+		for _, c := range row {
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:109
-		}
+			if c.Covered {
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:109
-		qw422016.N().S(`</text:p>
-    </table:table-cell>`)
+				qw422016.N().S(`<table:covered-table-cell/>`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:109
+				continue
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:109
+			}
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:109
+			qw422016.N().S(`<table:table-cell office:value-type="string"`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:109
+			if s := ow.getStyleName(c.Style); s != "" {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:109
+				qw422016.N().S(` table:style-name="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:109
+				qw422016.N().S(s)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:109
+				qw422016.N().S(`"`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:109
+			}
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:110
+			if c.ColSpan > 1 {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:110
+				qw422016.N().S(` table:number-columns-spanned="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:110
+				qw422016.N().D(c.ColSpan)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:110
+				qw422016.N().S(`"`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:110
+			}
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:111
-	}
+			if c.RowSpan > 1 {
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:111
-	qw422016.N().S(`</table:table-row>
-`)
+				qw422016.N().S(` table:number-rows-spanned="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:111
+				qw422016.N().D(c.RowSpan)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:111
+				qw422016.N().S(`"`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:111
+			}
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:111
+			qw422016.N().S(`><text:p>`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:111
+			StreamXML(qw422016, c.Text)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:111
+			qw422016.N().S(`</text:p></table:table-cell>`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:112
+		}
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:112
+		qw422016.N().S(`</table:table-row>`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:113
+	}
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:113
+	qw422016.N().S(`
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:114
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:112
-func WriteRow(qq422016 qtio422016.Writer, values ...interface{}) {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:112
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:114
+func (ow *ODSWriter) WriteBeginSheet(qq422016 qtio422016.Writer, name string, cols []spreadsheet.Column) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:114
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:112
-	StreamRow(qw422016, values...)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:112
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:114
+	ow.StreamBeginSheet(qw422016, name, cols)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:114
 	qt422016.ReleaseWriter(qw422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:112
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:114
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:112
-func Row(values ...interface{}) string {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:112
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:114
+func (ow *ODSWriter) BeginSheet(name string, cols []spreadsheet.Column) string {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:114
 	qb422016 := qt422016.AcquireByteBuffer()
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:112
-	WriteRow(qb422016, values...)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:112
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:114
+	ow.WriteBeginSheet(qb422016, name, cols)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:114
 	qs422016 := string(qb422016.B)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:112
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:114
 	qt422016.ReleaseByteBuffer(qb422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:112
-	return qs422016
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:112
-}
-
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:114
-func StreamEndSpreadsheet(qw422016 *qt422016.Writer) {
+	return qs422016
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:114
-	qw422016.N().S(`
-    </office:spreadsheet>
-  </office:body>
-</office:document-content>
-`)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:118
 }
 
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:116
+func (ods *ODSSheet) StreamEndSheet(qw422016 *qt422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:117
+	if len(ods.validations) != 0 && ods.rowCount < MaxRowCount {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:117
+		qw422016.N().S(`<table:table-row table:number-rows-repeated="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:117
+		qw422016.N().D(MaxRowCount - ods.rowCount)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:117
+		qw422016.N().S(`">`)
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:118
-func WriteEndSpreadsheet(qq422016 qtio422016.Writer) {
+		for i := range ods.validations {
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:118
-	qw422016 := qt422016.AcquireWriter(qq422016)
+			qw422016.N().S(`<table:table-cell`)
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:118
-	StreamEndSpreadsheet(qw422016)
+			ods.streamvalidation(qw422016, i)
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:118
-	qt422016.ReleaseWriter(qw422016)
+			qw422016.N().S(`/>`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:118
+		}
 //line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:118
+		qw422016.N().S(`</table:table-row>`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:119
+	}
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:119
+	qw422016.N().S(ods.conditionalFormats())
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:119
+	qw422016.N().S(`
+      </table:table>
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:121
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:118
-func EndSpreadsheet() string {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:118
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:121
+func (ods *ODSSheet) WriteEndSheet(qq422016 qtio422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:121
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:121
+	ods.StreamEndSheet(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:121
+	qt422016.ReleaseWriter(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:121
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:121
+func (ods *ODSSheet) EndSheet() string {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:121
 	qb422016 := qt422016.AcquireByteBuffer()
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:118
-	WriteEndSpreadsheet(qb422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:118
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:121
+	ods.WriteEndSheet(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:121
 	qs422016 := string(qb422016.B)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:118
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:121
 	qt422016.ReleaseByteBuffer(qb422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:118
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:121
 	return qs422016
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:118
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:121
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:123
+func (ods *ODSSheet) streamvalidation(qw422016 *qt422016.Writer, i int) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:124
+	if i < len(ods.validations) && ods.validations[i] != "" {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:124
+		qw422016.N().S(` table:content-validation-name="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:124
+		qw422016.E().S(ods.validations[i])
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:124
+		qw422016.N().S(`"`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:124
+	}
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:125
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:125
+func (ods *ODSSheet) writevalidation(qq422016 qtio422016.Writer, i int) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:125
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:125
+	ods.streamvalidation(qw422016, i)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:125
+	qt422016.ReleaseWriter(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:125
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:125
+func (ods *ODSSheet) validation(i int) string {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:125
+	qb422016 := qt422016.AcquireByteBuffer()
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:125
+	ods.writevalidation(qb422016, i)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:125
+	qs422016 := string(qb422016.B)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:125
+	qt422016.ReleaseByteBuffer(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:125
+	return qs422016
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:125
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:127
+func (ods *ODSSheet) streamcellAttrs(qw422016 *qt422016.Writer, i int) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:127
+	ods.streamvalidation(qw422016, i)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:127
+	ods.streamconditionalStyle(qw422016, i)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:128
+	if len(ods.merges) != 0 {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:129
+		colSpan, rowSpan, _ := ods.merged(i, ods.rowCount+1)
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:130
+		if colSpan > 1 {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:130
+			qw422016.N().S(` table:number-columns-spanned="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:130
+			qw422016.N().D(colSpan)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:130
+			qw422016.N().S(`"`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:130
+		}
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:131
+		if rowSpan > 1 {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:131
+			qw422016.N().S(` table:number-rows-spanned="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:131
+			qw422016.N().D(rowSpan)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:131
+			qw422016.N().S(`"`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:131
+		}
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:132
+	}
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:133
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:133
+func (ods *ODSSheet) writecellAttrs(qq422016 qtio422016.Writer, i int) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:133
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:133
+	ods.streamcellAttrs(qw422016, i)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:133
+	qt422016.ReleaseWriter(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:133
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:133
+func (ods *ODSSheet) cellAttrs(i int) string {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:133
+	qb422016 := qt422016.AcquireByteBuffer()
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:133
+	ods.writecellAttrs(qb422016, i)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:133
+	qs422016 := string(qb422016.B)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:133
+	qt422016.ReleaseByteBuffer(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:133
+	return qs422016
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:133
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:135
+func (ods *ODSSheet) streamconditionalStyle(qw422016 *qt422016.Writer, i int) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:136
+	if len(ods.conditions) != 0 && !ods.ow.extended() {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:137
+		if s := ods.cellStyle(i, ods.rowCount+1); s != "" {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:137
+			qw422016.N().S(` table:style-name="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:137
+			qw422016.E().S(s)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:137
+			qw422016.N().S(`"`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:137
+		}
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:138
+	}
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:139
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:139
+func (ods *ODSSheet) writeconditionalStyle(qq422016 qtio422016.Writer, i int) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:139
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:139
+	ods.streamconditionalStyle(qw422016, i)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:139
+	qt422016.ReleaseWriter(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:139
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:139
+func (ods *ODSSheet) conditionalStyle(i int) string {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:139
+	qb422016 := qt422016.AcquireByteBuffer()
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:139
+	ods.writeconditionalStyle(qb422016, i)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:139
+	qs422016 := string(qb422016.B)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:139
+	qt422016.ReleaseByteBuffer(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:139
+	return qs422016
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:139
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:142
+func (ods *ODSSheet) StreamRow(qw422016 *qt422016.Writer, values ...interface{}) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:142
+	qw422016.N().S(`<table:table-row>`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:143
+	ow := ods.ow
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:144
+	for i, v := range values {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:144
+		v = format.Value(v)
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:145
+		tag := ods.cellTag(i)
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:146
+		if v == nil {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:146
+			qw422016.N().S(`<`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:146
+			qw422016.E().S(tag)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:146
+			ods.streamcellAttrs(qw422016, i)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:146
+			qw422016.N().S(`/>`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:146
+			continue
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:146
+		}
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:146
+		typ := getValueType(v)
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:146
+		qw422016.N().S(`
+	<`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:147
+		qw422016.E().S(tag)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:147
+		ods.streamcellAttrs(qw422016, i)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:147
+		qw422016.N().S(` `)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:148
+		if typ == FloatType {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:148
+			qw422016.N().S(` office:value-type="float" office:value="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:148
+			qw422016.N().S(fmt.Sprintf("%v", v))
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:148
+			qw422016.N().S(`"`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:148
+			if ow.extended() {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:148
+				qw422016.N().S(` calcext:value-type="float"`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:148
+			}
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:149
+		} else if false && typ == DateType {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:149
+			qw422016.N().S(` office:value-type="date" office:date-value="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:149
+			streamgetDateValue(qw422016, v)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:149
+			qw422016.N().S(`"`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:149
+			if ow.extended() {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:149
+				qw422016.N().S(` calcext:value-type="date"`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:149
+			}
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:150
+		} else {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:150
+			qw422016.N().S(` office:value-type="string"`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:151
+		}
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:151
+		qw422016.N().S(` ><text:p>`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:151
+		text := getText(v)
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:152
+		if typ == LinkType {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:152
+			qw422016.N().S(`<text:a xlink:href="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:152
+			qw422016.N().S(text)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:152
+			qw422016.N().S(`">`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:152
+			qw422016.N().S(text)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:152
+			qw422016.N().S(`</text:a>`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:153
+		} else {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:153
+			qw422016.N().S(text)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:154
+		}
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:154
+		qw422016.N().S(`</text:p>
+    </`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:155
+		qw422016.E().S(tag)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:155
+		qw422016.N().S(`>`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:156
+	}
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:157
+	for i := len(values); i < max(len(ods.validations), ods.mergedColumns); i++ {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:157
+		qw422016.N().S(`<`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:157
+		qw422016.E().S(ods.cellTag(i))
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:157
+		ods.streamcellAttrs(qw422016, i)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:157
+		qw422016.N().S(`/>`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:157
+	}
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:157
+	qw422016.N().S(`</table:table-row>
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:158
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:158
+func (ods *ODSSheet) WriteRow(qq422016 qtio422016.Writer, values ...interface{}) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:158
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:158
+	ods.StreamRow(qw422016, values...)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:158
+	qt422016.ReleaseWriter(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:158
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:158
+func (ods *ODSSheet) Row(values ...interface{}) string {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:158
+	qb422016 := qt422016.AcquireByteBuffer()
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:158
+	ods.WriteRow(qb422016, values...)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:158
+	qs422016 := string(qb422016.B)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:158
+	qt422016.ReleaseByteBuffer(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:158
+	return qs422016
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:158
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:160
+func StreamEndSpreadsheet(qw422016 *qt422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:160
+	streamendBody(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:160
+	qw422016.N().S(`</office:document-content>
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:161
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:161
+func WriteEndSpreadsheet(qq422016 qtio422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:161
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:161
+	StreamEndSpreadsheet(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:161
+	qt422016.ReleaseWriter(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:161
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:120
-func StreamStyles(qw422016 *qt422016.Writer, styles map[string]string) {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:120
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:161
+func EndSpreadsheet() string {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:161
+	qb422016 := qt422016.AcquireByteBuffer()
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:161
+	WriteEndSpreadsheet(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:161
+	qs422016 := string(qb422016.B)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:161
+	qt422016.ReleaseByteBuffer(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:161
+	return qs422016
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:161
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:163
+func streamendBody(qw422016 *qt422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:163
+	qw422016.N().S(`
+    </office:spreadsheet>
+  </office:body>
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:166
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:166
+func writeendBody(qq422016 qtio422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:166
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:166
+	streamendBody(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:166
+	qt422016.ReleaseWriter(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:166
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:166
+func endBody() string {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:166
+	qb422016 := qt422016.AcquireByteBuffer()
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:166
+	writeendBody(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:166
+	qs422016 := string(qb422016.B)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:166
+	qt422016.ReleaseByteBuffer(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:166
+	return qs422016
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:166
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:168
+func (ow *ODSWriter) StreamStyles(qw422016 *qt422016.Writer, styles map[string]string) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:168
 	qw422016.N().S(`<?xml version="1.0" encoding="UTF-8"?>
-<office:document-styles xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:draw="urn:oasis:names:tc:opendocument:xmlns:drawing:1.0" xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0" xmlns:xlink="http://www.w3.org/1999/xlink" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:meta="urn:oasis:names:tc:opendocument:xmlns:meta:1.0" xmlns:number="urn:oasis:names:tc:opendocument:xmlns:datastyle:1.0" xmlns:svg="urn:oasis:names:tc:opendocument:xmlns:svg-compatible:1.0" xmlns:chart="urn:oasis:names:tc:opendocument:xmlns:chart:1.0" xmlns:dr3d="urn:oasis:names:tc:opendocument:xmlns:dr3d:1.0" xmlns:config="urn:oasis:names:tc:opendocument:xmlns:config:1.0" xmlns:math="http://www.w3.org/1998/Math/MathML" xmlns:form="urn:oasis:names:tc:opendocument:xmlns:form:1.0" xmlns:script="urn:oasis:names:tc:opendocument:xmlns:script:1.0" xmlns:ooo="http://openoffice.org/2004/office" xmlns:ooow="http://openoffice.org/2004/writer" xmlns:oooc="http://openoffice.org/2004/calc" xmlns:tableooo="http://openoffice.org/2009/table" xmlns:of="urn:oasis:names:tc:opendocument:xmlns:of:1.2" xmlns:dom="http://www.w3.org/2001/xml-events" xmlns:xforms="http://www.w3.org/2002/xforms" xmlns:xsd="http://www.w3.org/2001/XMLSchema" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns:gnm="http://www.gnumeric.org/odf-extension/1.0" xmlns:css3t="http://www.w3.org/TR/css3-text/" xmlns:loext="urn:org:documentfoundation:names:experimental:office:xmlns:loext:1.0" xmlns:calcext="urn:org:documentfoundation:names:experimental:calc:xmlns:calcext:1.0" office:version="1.2">
-  <office:styles>
+<office:document-styles`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:169
+	ow.streamnamespaces(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:169
+	qw422016.N().S(` office:version="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:169
+	qw422016.E().S(ow.version)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:169
+	qw422016.N().S(`">
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:170
+	streamstylesBody(qw422016, styles, ow.conditionStyles)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:170
+	qw422016.N().S(`</office:document-styles>
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:171
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:171
+func (ow *ODSWriter) WriteStyles(qq422016 qtio422016.Writer, styles map[string]string) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:171
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:171
+	ow.StreamStyles(qw422016, styles)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:171
+	qt422016.ReleaseWriter(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:171
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:171
+func (ow *ODSWriter) Styles(styles map[string]string) string {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:171
+	qb422016 := qt422016.AcquireByteBuffer()
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:171
+	ow.WriteStyles(qb422016, styles)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:171
+	qs422016 := string(qb422016.B)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:171
+	qt422016.ReleaseByteBuffer(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:171
+	return qs422016
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:171
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:173
+func streamstylesBody(qw422016 *qt422016.Writer, styles, common map[string]string) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:173
+	qw422016.N().S(`  <office:styles>
     <style:default-style style:family="table-column">
       <style:table-column-properties style:use-optimal-column-width="true"/>
     </style:default-style>
     <style:default-style style:family="table-row">
       <style:table-row-properties style:use-optimal-row-height="true"/>
     </style:default-style>
+	`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:180
+	for _, s := range common {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:180
+		qw422016.N().S(s)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:181
+	}
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:181
+	qw422016.N().S(`
   </office:styles>
   <office:automatic-styles>
 	`)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:131
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:184
 	for _, s := range styles {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:131
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:184
 		qw422016.N().S(s)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:132
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:185
 	}
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:132
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:185
 	qw422016.N().S(`
   </office:automatic-styles>
-</office:document-styles>
 `)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:135
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:187
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:135
-func WriteStyles(qq422016 qtio422016.Writer, styles map[string]string) {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:135
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:187
+func writestylesBody(qq422016 qtio422016.Writer, styles, common map[string]string) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:187
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:135
-	StreamStyles(qw422016, styles)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:135
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:187
+	streamstylesBody(qw422016, styles, common)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:187
 	qt422016.ReleaseWriter(qw422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:135
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:187
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:135
-func Styles(styles map[string]string) string {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:135
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:187
+func stylesBody(styles, common map[string]string) string {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:187
 	qb422016 := qt422016.AcquireByteBuffer()
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:135
-	WriteStyles(qb422016, styles)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:135
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:187
+	writestylesBody(qb422016, styles, common)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:187
 	qs422016 := string(qb422016.B)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:135
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:187
 	qt422016.ReleaseByteBuffer(qb422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:135
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:187
 	return qs422016
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:135
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:187
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:137
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:189
+func streamprotectionKey(qw422016 *qt422016.Writer, password string) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:189
+	if password != "" {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:190
+		key := sha256.Sum256([]byte(password))
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:190
+		qw422016.N().S(` table:protection-key="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:190
+		qw422016.E().S(base64.StdEncoding.EncodeToString(key[:]))
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:190
+		qw422016.N().S(`" table:protection-key-digest-algorithm="http://www.w3.org/2000/09/xmldsig#sha256"`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:191
+	}
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:191
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:191
+func writeprotectionKey(qq422016 qtio422016.Writer, password string) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:191
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:191
+	streamprotectionKey(qw422016, password)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:191
+	qt422016.ReleaseWriter(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:191
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:191
+func protectionKey(password string) string {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:191
+	qb422016 := qt422016.AcquireByteBuffer()
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:191
+	writeprotectionKey(qb422016, password)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:191
+	qs422016 := string(qb422016.B)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:191
+	qt422016.ReleaseByteBuffer(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:191
+	return qs422016
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:191
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:193
 func StreamMimetype(qw422016 *qt422016.Writer) {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:137
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:193
 	qw422016.N().S(`application/vnd.oasis.opendocument.spreadsheet`)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:137
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:193
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:137
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:193
 func WriteMimetype(qq422016 qtio422016.Writer) {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:137
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:193
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:137
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:193
 	StreamMimetype(qw422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:137
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:193
 	qt422016.ReleaseWriter(qw422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:137
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:193
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:137
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:193
 func Mimetype() string {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:137
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:193
 	qb422016 := qt422016.AcquireByteBuffer()
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:137
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:193
 	WriteMimetype(qb422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:137
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:193
 	qs422016 := string(qb422016.B)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:137
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:193
 	qt422016.ReleaseByteBuffer(qb422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:137
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:193
 	return qs422016
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:137
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:193
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:139
-func StreamMeta(qw422016 *qt422016.Writer) {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:139
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:195
+func (ow *ODSWriter) StreamMeta(qw422016 *qt422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:195
 	qw422016.N().S(`<?xml version="1.0" encoding="UTF-8"?>
-<office:document-meta xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:xlink="http://www.w3.org/1999/xlink" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:meta="urn:oasis:names:tc:opendocument:xmlns:meta:1.0" xmlns:ooo="http://openoffice.org/2004/office" office:version="1.2">
-  <office:meta>
+<office:document-meta xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:xlink="http://www.w3.org/1999/xlink" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:meta="urn:oasis:names:tc:opendocument:xmlns:meta:1.0" xmlns:ooo="http://openoffice.org/2004/office" office:version="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:196
+	qw422016.E().S(ow.version)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:196
+	qw422016.N().S(`">
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:197
+	streammetaBody(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:197
+	qw422016.N().S(`
+</office:document-meta>`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:198
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:198
+func (ow *ODSWriter) WriteMeta(qq422016 qtio422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:198
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:198
+	ow.StreamMeta(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:198
+	qt422016.ReleaseWriter(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:198
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:198
+func (ow *ODSWriter) Meta() string {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:198
+	qb422016 := qt422016.AcquireByteBuffer()
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:198
+	ow.WriteMeta(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:198
+	qs422016 := string(qb422016.B)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:198
+	qt422016.ReleaseByteBuffer(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:198
+	return qs422016
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:198
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:200
+func streammetaBody(qw422016 *qt422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:200
+	qw422016.N().S(`  <office:meta>
     <dc:date>`)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:142
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:201
 	t := time.Now()
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:142
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:201
 	qw422016.N().S(t.Format(time.RFC3339))
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:142
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:201
 	qw422016.N().S(`</dc:date>
     <meta:creation-date>`)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:143
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:202
 	qw422016.N().S(t.Format(time.RFC3339))
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:143
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:202
 	qw422016.N().S(`</meta:creation-date>
     <meta:generator>github.com/tgulacsi/go/spreadsheet/ods</meta:generator>
-  </office:meta>
-</office:document-meta>`)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:146
+  </office:meta>`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:204
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:146
-func WriteMeta(qq422016 qtio422016.Writer) {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:146
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:204
+func writemetaBody(qq422016 qtio422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:204
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:146
-	StreamMeta(qw422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:146
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:204
+	streammetaBody(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:204
 	qt422016.ReleaseWriter(qw422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:146
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:204
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:146
-func Meta() string {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:146
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:204
+func metaBody() string {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:204
 	qb422016 := qt422016.AcquireByteBuffer()
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:146
-	WriteMeta(qb422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:146
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:204
+	writemetaBody(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:204
 	qs422016 := string(qb422016.B)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:146
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:204
 	qt422016.ReleaseByteBuffer(qb422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:146
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:204
 	return qs422016
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:146
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:204
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:148
-func StreamManifest(qw422016 *qt422016.Writer) {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:148
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:206
+func (ow *ODSWriter) StreamManifest(qw422016 *qt422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:206
 	qw422016.N().S(`<?xml version="1.0" encoding="UTF-8"?>
-<manifest:manifest xmlns:manifest="urn:oasis:names:tc:opendocument:xmlns:manifest:1.0" manifest:version="1.2">
-  <manifest:file-entry manifest:media-type="application/vnd.oasis.opendocument.spreadsheet" manifest:full-path="/"/>
-  <manifest:file-entry manifest:media-type="text/xml" manifest:full-path="meta.xml"/>
-  <manifest:file-entry manifest:media-type="text/xml" manifest:full-path="content.xml"/>
-  <manifest:file-entry manifest:media-type="text/xml" manifest:full-path="styles.xml"/>
-  <manifest:file-entry manifest:media-type="text/xml" manifest:full-path="settings.xml"/>
-</manifest:manifest>`)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:155
+<manifest:manifest xmlns:manifest="urn:oasis:names:tc:opendocument:xmlns:manifest:1.0" manifest:version="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:207
+	qw422016.E().S(ow.version)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:207
+	qw422016.N().S(`">
+  <manifest:file-entry manifest:media-type="application/vnd.oasis.opendocument.spreadsheet" manifest:version="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:208
+	qw422016.E().S(ow.version)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:208
+	qw422016.N().S(`" manifest:full-path="/"/>
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:209
+	for _, name := range []string{"meta.xml", "content.xml", "styles.xml", "settings.xml"} {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:210
+		if ep := ow.encryption(name); ep == nil {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:210
+			qw422016.N().S(`  <manifest:file-entry manifest:media-type="text/xml" manifest:full-path="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:210
+			qw422016.E().S(name)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:210
+			qw422016.N().S(`"/>
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:211
+		} else {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:211
+			qw422016.N().S(`  <manifest:file-entry manifest:media-type="text/xml" manifest:full-path="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:211
+			qw422016.E().S(name)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:211
+			qw422016.N().S(`" manifest:size="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:211
+			qw422016.N().DL(ep.data.Size)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:211
+			qw422016.N().S(`">
+    <manifest:encryption-data manifest:checksum-type="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:212
+			qw422016.E().S(encChecksumType)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:212
+			qw422016.N().S(`" manifest:checksum="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:212
+			qw422016.E().S(base64.StdEncoding.EncodeToString(ep.data.Checksum))
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:212
+			qw422016.N().S(`">
+      <manifest:algorithm manifest:algorithm-name="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:213
+			qw422016.E().S(encAlgorithm)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:213
+			qw422016.N().S(`" manifest:initialisation-vector="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:213
+			qw422016.E().S(base64.StdEncoding.EncodeToString(ep.data.IV))
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:213
+			qw422016.N().S(`"/>
+      <manifest:start-key-generation manifest:start-key-generation-name="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:214
+			qw422016.E().S(encStartKey)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:214
+			qw422016.N().S(`" manifest:key-size="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:214
+			qw422016.N().D(keySize)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:214
+			qw422016.N().S(`"/>
+      <manifest:key-derivation manifest:key-derivation-name="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:215
+			qw422016.E().S(encKeyDerivation)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:215
+			qw422016.N().S(`" manifest:key-size="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:215
+			qw422016.N().D(keySize)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:215
+			qw422016.N().S(`" manifest:iteration-count="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:215
+			qw422016.N().D(encIterCount)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:215
+			qw422016.N().S(`" manifest:salt="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:215
+			qw422016.E().S(base64.StdEncoding.EncodeToString(ep.data.Salt))
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:215
+			qw422016.N().S(`"/>
+    </manifest:encryption-data>
+  </manifest:file-entry>
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:218
+		}
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:218
+	}
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:218
+	qw422016.N().S(`</manifest:manifest>`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:218
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:155
-func WriteManifest(qq422016 qtio422016.Writer) {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:155
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:218
+func (ow *ODSWriter) WriteManifest(qq422016 qtio422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:218
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:155
-	StreamManifest(qw422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:155
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:218
+	ow.StreamManifest(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:218
 	qt422016.ReleaseWriter(qw422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:155
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:218
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:155
-func Manifest() string {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:155
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:218
+func (ow *ODSWriter) Manifest() string {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:218
 	qb422016 := qt422016.AcquireByteBuffer()
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:155
-	WriteManifest(qb422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:155
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:218
+	ow.WriteManifest(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:218
 	qs422016 := string(qb422016.B)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:155
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:218
 	qt422016.ReleaseByteBuffer(qb422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:155
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:218
 	return qs422016
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:155
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:218
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:157
-func StreamSettings(qw422016 *qt422016.Writer) {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:157
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:220
+func (ow *ODSWriter) StreamSettings(qw422016 *qt422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:220
 	qw422016.N().S(`<?xml version="1.0" encoding="UTF-8"?>
-<office:document-settings xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:draw="urn:oasis:names:tc:opendocument:xmlns:drawing:1.0" xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0" xmlns:xlink="http://www.w3.org/1999/xlink" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:meta="urn:oasis:names:tc:opendocument:xmlns:meta:1.0" xmlns:number="urn:oasis:names:tc:opendocument:xmlns:datastyle:1.0" xmlns:svg="urn:oasis:names:tc:opendocument:xmlns:svg-compatible:1.0" xmlns:chart="urn:oasis:names:tc:opendocument:xmlns:chart:1.0" xmlns:dr3d="urn:oasis:names:tc:opendocument:xmlns:dr3d:1.0" xmlns:config="urn:oasis:names:tc:opendocument:xmlns:config:1.0" xmlns:math="http://www.w3.org/1998/Math/MathML" xmlns:form="urn:oasis:names:tc:opendocument:xmlns:form:1.0" xmlns:script="urn:oasis:names:tc:opendocument:xmlns:script:1.0" xmlns:ooo="http://openoffice.org/2004/office" xmlns:ooow="http://openoffice.org/2004/writer" xmlns:oooc="http://openoffice.org/2004/calc" xmlns:tableooo="http://openoffice.org/2009/table" xmlns:of="urn:oasis:names:tc:opendocument:xmlns:of:1.2" xmlns:dom="http://www.w3.org/2001/xml-events" xmlns:xforms="http://www.w3.org/2002/xforms" xmlns:xsd="http://www.w3.org/2001/XMLSchema" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns:gnm="http://www.gnumeric.org/odf-extension/1.0" xmlns:css3t="http://www.w3.org/TR/css3-text/" xmlns:loext="urn:org:documentfoundation:names:experimental:office:xmlns:loext:1.0" xmlns:calcext="urn:org:documentfoundation:names:experimental:calc:xmlns:calcext:1.0" office:version="1.2">
-  <office:settings>
+<office:document-settings`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:221
+	ow.streamnamespaces(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:221
+	qw422016.N().S(` office:version="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:221
+	qw422016.E().S(ow.version)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:221
+	qw422016.N().S(`">
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:222
+	streamsettingsBody(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:222
+	qw422016.N().S(`</office:document-settings>
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:223
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:223
+func (ow *ODSWriter) WriteSettings(qq422016 qtio422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:223
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:223
+	ow.StreamSettings(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:223
+	qt422016.ReleaseWriter(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:223
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:223
+func (ow *ODSWriter) Settings() string {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:223
+	qb422016 := qt422016.AcquireByteBuffer()
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:223
+	ow.WriteSettings(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:223
+	qs422016 := string(qb422016.B)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:223
+	qt422016.ReleaseByteBuffer(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:223
+	return qs422016
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:223
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:225
+func streamsettingsBody(qw422016 *qt422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:225
+	qw422016.N().S(`  <office:settings>
     <config:config-item-set config:name="gnm:settings">
       <config:config-item config:name="gnm:has_foreign" config:type="boolean">false</config:config-item>
       <config:config-item config:name="gnm:active-sheet" config:type="string">Sheet1</config:config-item>
@@ -721,33 +1417,167 @@ func StreamSettings(qw422016 *qt422016.Writer) {
       </config:config-item-map-indexed>
     </config:config-item-set>
   </office:settings>
-</office:document-settings>
 `)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:190
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:255
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:190
-func WriteSettings(qq422016 qtio422016.Writer) {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:190
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:255
+func writesettingsBody(qq422016 qtio422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:255
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:190
-	StreamSettings(qw422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:190
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:255
+	streamsettingsBody(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:255
 	qt422016.ReleaseWriter(qw422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:190
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:255
 }
 
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:190
-func Settings() string {
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:190
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:255
+func settingsBody() string {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:255
 	qb422016 := qt422016.AcquireByteBuffer()
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:190
-	WriteSettings(qb422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:190
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:255
+	writesettingsBody(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:255
 	qs422016 := string(qb422016.B)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:190
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:255
 	qt422016.ReleaseByteBuffer(qb422016)
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:190
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:255
 	return qs422016
-//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:190
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:255
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:257
+func (ow *ODSWriter) streamnamespaces(qw422016 *qt422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:257
+	qw422016.N().S(` xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:draw="urn:oasis:names:tc:opendocument:xmlns:drawing:1.0" xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0" xmlns:xlink="http://www.w3.org/1999/xlink" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:meta="urn:oasis:names:tc:opendocument:xmlns:meta:1.0" xmlns:number="urn:oasis:names:tc:opendocument:xmlns:datastyle:1.0" xmlns:svg="urn:oasis:names:tc:opendocument:xmlns:svg-compatible:1.0" xmlns:chart="urn:oasis:names:tc:opendocument:xmlns:chart:1.0" xmlns:dr3d="urn:oasis:names:tc:opendocument:xmlns:dr3d:1.0" xmlns:config="urn:oasis:names:tc:opendocument:xmlns:config:1.0" xmlns:math="http://www.w3.org/1998/Math/MathML" xmlns:form="urn:oasis:names:tc:opendocument:xmlns:form:1.0" xmlns:script="urn:oasis:names:tc:opendocument:xmlns:script:1.0" xmlns:ooo="http://openoffice.org/2004/office" xmlns:ooow="http://openoffice.org/2004/writer" xmlns:oooc="http://openoffice.org/2004/calc" xmlns:tableooo="http://openoffice.org/2009/table" xmlns:of="urn:oasis:names:tc:opendocument:xmlns:of:1.2" xmlns:dom="http://www.w3.org/2001/xml-events" xmlns:xforms="http://www.w3.org/2002/xforms" xmlns:xsd="http://www.w3.org/2001/XMLSchema" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns:gnm="http://www.gnumeric.org/odf-extension/1.0" xmlns:css3t="http://www.w3.org/TR/css3-text/"`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:257
+	if ow.extended() {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:257
+		qw422016.N().S(` xmlns:loext="urn:org:documentfoundation:names:experimental:office:xmlns:loext:1.0" xmlns:calcext="urn:org:documentfoundation:names:experimental:calc:xmlns:calcext:1.0"`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:257
+	}
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:257
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:257
+func (ow *ODSWriter) writenamespaces(qq422016 qtio422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:257
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:257
+	ow.streamnamespaces(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:257
+	qt422016.ReleaseWriter(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:257
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:257
+func (ow *ODSWriter) namespaces() string {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:257
+	qb422016 := qt422016.AcquireByteBuffer()
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:257
+	ow.writenamespaces(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:257
+	qs422016 := string(qb422016.B)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:257
+	qt422016.ReleaseByteBuffer(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:257
+	return qs422016
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:257
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:259
+func (ow *ODSWriter) StreamBeginFlat(qw422016 *qt422016.Writer, styles map[string]string) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:259
+	qw422016.N().S(`<?xml version="1.0" encoding="UTF-8"?>
+<office:document`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:260
+	ow.streamnamespaces(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:260
+	qw422016.N().S(` office:version="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:260
+	qw422016.E().S(ow.version)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:260
+	qw422016.N().S(`" office:mimetype="`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:260
+	StreamMimetype(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:260
+	qw422016.N().S(`">
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:261
+	streammetaBody(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:261
+	qw422016.N().S(`
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:262
+	streamsettingsBody(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:262
+	qw422016.N().S(`  <office:scripts/>
+  <office:font-face-decls/>
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:264
+	streamstylesBody(qw422016, styles, ow.conditionStyles)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:264
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:264
+func (ow *ODSWriter) WriteBeginFlat(qq422016 qtio422016.Writer, styles map[string]string) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:264
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:264
+	ow.StreamBeginFlat(qw422016, styles)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:264
+	qt422016.ReleaseWriter(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:264
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:264
+func (ow *ODSWriter) BeginFlat(styles map[string]string) string {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:264
+	qb422016 := qt422016.AcquireByteBuffer()
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:264
+	ow.WriteBeginFlat(qb422016, styles)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:264
+	qs422016 := string(qb422016.B)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:264
+	qt422016.ReleaseByteBuffer(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:264
+	return qs422016
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:264
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:266
+func StreamEndFlat(qw422016 *qt422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:266
+	streamendBody(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:266
+	qw422016.N().S(`</office:document>
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:267
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:267
+func WriteEndFlat(qq422016 qtio422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:267
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:267
+	StreamEndFlat(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:267
+	qt422016.ReleaseWriter(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:267
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:267
+func EndFlat() string {
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:267
+	qb422016 := qt422016.AcquireByteBuffer()
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:267
+	WriteEndFlat(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:267
+	qs422016 := string(qb422016.B)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:267
+	qt422016.ReleaseByteBuffer(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:267
+	return qs422016
+//line src/github.com/UNO-SOFT/spreadsheet/ods/content.xml.qtpl:267
 }
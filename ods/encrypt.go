@@ -0,0 +1,171 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ods
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"hash"
+	"hash/crc32"
+	"io"
+	"os"
+	"time"
+
+	"github.com/klauspost/compress/flate"
+	"github.com/klauspost/compress/zip"
+)
+
+// The package encryption parameters, as LibreOffice writes them:
+// AES-256-CBC with W3C padding, the key derived by PBKDF2 (HMAC-SHA1)
+// from the SHA-256 of the password.
+const (
+	encAlgorithm     = "http://www.w3.org/2001/04/xmlenc#aes256-cbc"
+	encStartKey      = "http://www.w3.org/2000/09/xmldsig#sha256"
+	encKeyDerivation = "PBKDF2"
+	encChecksumType  = "urn:oasis:names:tc:opendocument:xmlns:manifest:1.0#sha256-1k"
+	encIterCount     = 100_000
+
+	keySize  = 32
+	saltSize = 16
+)
+
+// encryptionData is the manifest:encryption-data of an encrypted part.
+type encryptionData struct {
+	Checksum, IV, Salt []byte
+	// Size is the size of the uncompressed, unencrypted part.
+	Size int64
+}
+
+// encryptedPart is a part of the package, deflated then encrypted into a temporary file,
+// as the stored zip entry's size and CRC must be known before writing it.
+type encryptedPart struct {
+	name     string
+	f        *os.File
+	fw       *flate.Writer
+	cw       *cbcWriter
+	checksum hash.Hash
+	crc      hash.Hash32
+	data     encryptionData
+	size     int64 // the encrypted size
+}
+
+func newEncryptedPart(name, password string) (*encryptedPart, error) {
+	ep := encryptedPart{name: name, checksum: sha256.New(), crc: crc32.NewIEEE()}
+	ep.data.IV = make([]byte, aes.BlockSize)
+	ep.data.Salt = make([]byte, saltSize)
+	rand.Read(ep.data.IV)
+	rand.Read(ep.data.Salt)
+	startKey := sha256.Sum256([]byte(password))
+	key, err := pbkdf2.Key(sha1.New, string(startKey[:]), ep.data.Salt, encIterCount, keySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if ep.f, err = os.CreateTemp("", "spreadsheet-ods-*.enc"); err != nil {
+		return nil, err
+	}
+	os.Remove(ep.f.Name())
+	ep.cw = &cbcWriter{
+		w:    io.MultiWriter(ep.f, ep.crc, countWriter{&ep.size}),
+		mode: cipher.NewCBCEncrypter(block, ep.data.IV),
+	}
+	// the checksum is of the first 1024 bytes of the compressed, unencrypted data
+	ep.fw, _ = flate.NewWriter(io.MultiWriter(ep.cw, &limitWriter{w: ep.checksum, n: 1024}), flate.DefaultCompression)
+	return &ep, nil
+}
+
+func (ep *encryptedPart) Write(p []byte) (int, error) {
+	n, err := ep.fw.Write(p)
+	ep.data.Size += int64(n)
+	return n, err
+}
+
+// finish the compression and the encryption.
+func (ep *encryptedPart) finish() error {
+	if err := ep.fw.Close(); err != nil {
+		return err
+	}
+	if err := ep.cw.Close(); err != nil {
+		return err
+	}
+	ep.data.Checksum = ep.checksum.Sum(nil)
+	return nil
+}
+
+// copyTo stores the encrypted part in the zip.
+func (ep *encryptedPart) copyTo(zw *zip.Writer, now time.Time) error {
+	defer ep.f.Close()
+	if _, err := ep.f.Seek(0, 0); err != nil {
+		return err
+	}
+	w, err := zw.CreateRaw(&zip.FileHeader{
+		Name: ep.name, Method: zip.Store, Modified: now,
+		CRC32:              ep.crc.Sum32(),
+		CompressedSize64:   uint64(ep.size),
+		UncompressedSize64: uint64(ep.size),
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, ep.f)
+	return err
+}
+
+// cbcWriter encrypts in CBC mode, with W3C padding on Close.
+type cbcWriter struct {
+	w    io.Writer
+	mode cipher.BlockMode
+	buf  []byte
+}
+
+func (cw *cbcWriter) Write(p []byte) (int, error) {
+	cw.buf = append(cw.buf, p...)
+	n := len(cw.buf) - len(cw.buf)%aes.BlockSize
+	if n == 0 {
+		return len(p), nil
+	}
+	cw.mode.CryptBlocks(cw.buf[:n], cw.buf[:n])
+	if _, err := cw.w.Write(cw.buf[:n]); err != nil {
+		return 0, err
+	}
+	cw.buf = append(cw.buf[:0], cw.buf[n:]...)
+	return len(p), nil
+}
+
+// Close writes the last, padded block: the padding is random, but its last byte is its length.
+func (cw *cbcWriter) Close() error {
+	pad := make([]byte, aes.BlockSize-len(cw.buf))
+	rand.Read(pad)
+	pad[len(pad)-1] = byte(len(pad))
+	cw.buf = append(cw.buf, pad...)
+	_, err := cw.Write(nil)
+	return err
+}
+
+type countWriter struct{ n *int64 }
+
+func (cw countWriter) Write(p []byte) (int, error) { *cw.n += int64(len(p)); return len(p), nil }
+
+// limitWriter writes only the first n bytes into w.
+type limitWriter struct {
+	w io.Writer
+	n int
+}
+
+func (lw *limitWriter) Write(p []byte) (int, error) {
+	if lw.n > 0 {
+		q := p[:min(len(p), lw.n)]
+		lw.n -= len(q)
+		lw.w.Write(q)
+	}
+	return len(p), nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ods
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/UNO-SOFT/spreadsheet"
+)
+
+var _ = (spreadsheet.CellMerger)((*ODSSheet)(nil))
+
+// MergeCells merges the cells of the range, as described at spreadsheet.CellMerger.
+//
+// The covered cells keep their values, as LibreOffice does.
+func (ods *ODSSheet) MergeCells(ref string) error {
+	r, err := parseRange(ref)
+	if err != nil {
+		return err
+	}
+	ods.mu.Lock()
+	defer ods.mu.Unlock()
+	if ods.ow == nil {
+		return os.ErrClosed
+	}
+	if r.last.row > MaxRowCount {
+		return fmt.Errorf("MergeCells(%q): more than %d rows", ref, MaxRowCount)
+	}
+	if r.first.row <= ods.rowCount {
+		return fmt.Errorf("MergeCells(%q): the first %d rows are already appended", ref, ods.rowCount)
+	}
+	for _, m := range ods.merges {
+		if m.overlaps(r) {
+			return fmt.Errorf("MergeCells(%q): overlaps %s", ref, m)
+		}
+	}
+	ods.merges = append(ods.merges, r)
+	ods.mergedColumns = max(ods.mergedColumns, r.last.col+1)
+	return nil
+}
+
+// merged returns the spans of the cell if it is the top-left cell of a merged range,
+// and whether the cell is covered by a merged range.
+//
+// Must be called with ods.mu held.
+func (ods *ODSSheet) merged(col, row int) (colSpan, rowSpan int, covered bool) {
+	for _, m := range ods.merges {
+		if !m.covers(col, row) {
+			continue
+		}
+		if m.first.col == col && m.first.row == row {
+			return m.last.col - m.first.col + 1, m.last.row - m.first.row + 1, false
+		}
+		return 1, 1, true
+	}
+	return 1, 1, false
+}
+
+// appendMerged appends the empty rows up to the last row of the merged ranges,
+// so no span points below the rows of the sheet, or into the validations' repeated row.
+//
+// Must be called with ods.mu held.
+func (ods *ODSSheet) appendMerged() {
+	var last int
+	for _, m := range ods.merges {
+		last = max(last, m.last.row)
+	}
+	for ods.rowCount < last {
+		ods.StreamRow(ods.w)
+		ods.rowCount++
+	}
+}
+
+// cellTag returns the element name of the cell in the row being appended.
+func (ods *ODSSheet) cellTag(col int) string {
+	if len(ods.merges) != 0 {
+		if _, _, covered := ods.merged(col, ods.rowCount+1); covered {
+			return "table:covered-table-cell"
+		}
+	}
+	return "table:table-cell"
+}
@@ -6,10 +6,13 @@ package ods
 
 import (
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"hash/crc32"
 	"hash/fnv"
 	"io"
 	"os"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -19,9 +22,37 @@ import (
 	qt "github.com/valyala/quicktemplate"
 
 	"github.com/UNO-SOFT/spreadsheet"
+	"github.com/UNO-SOFT/spreadsheet/internal/header"
+	"github.com/UNO-SOFT/spreadsheet/internal/spool"
 )
 
 var _ = fmt.Errorf
+var _ = (spreadsheet.Protector)((*ODSWriter)(nil))
+
+func init() {
+	spreadsheet.Register(spreadsheet.Format{
+		Name: "ods", Extensions: []string{".ods"},
+		MIMETypes: []string{"application/vnd.oasis.opendocument.spreadsheet"},
+		New: func(w io.Writer, _ spreadsheet.Options) (spreadsheet.Writer, error) {
+			ow, err := NewWriter(w)
+			if err != nil {
+				return nil, err
+			}
+			return ow, nil
+		},
+	})
+	spreadsheet.Register(spreadsheet.Format{
+		Name: "fods", Extensions: []string{".fods"},
+		MIMETypes: []string{"application/vnd.oasis.opendocument.spreadsheet-flat-xml"},
+		New: func(w io.Writer, _ spreadsheet.Options) (spreadsheet.Writer, error) {
+			ow, err := NewFlatWriter(w)
+			if err != nil {
+				return nil, err
+			}
+			return ow, nil
+		},
+	})
+}
 
 //go:generate qtc
 
@@ -82,20 +113,107 @@ var (
 	StringType = ValueType{'s'}
 )
 
+// Option is an option for the ods writer.
+type Option func(*options)
+
+type options struct {
+	version  string
+	password string
+	validate bool
+}
+
+// The supported ODF versions.
+const (
+	Version12 = "1.2"
+	Version13 = "1.3"
+
+	// DefaultVersion is the default ODF version, for compatibility.
+	DefaultVersion = Version12
+)
+
+// WithVersion sets the ODF version of the document (Version12 or Version13).
+//
+// ODF 1.2 output uses the LibreOffice extension namespaces (such as calcext) where
+// they help the readers, ODF 1.3 output uses the standard namespaces only.
+func WithVersion(version string) Option { return func(o *options) { o.version = version } }
+
+// WithPassword encrypts the document with the password (AES-256-CBC, with PBKDF2 key derivation),
+// as LibreOffice does, so it asks for the password on open.
+//
+// Only the ods package can be encrypted, the flat XML can not.
+func WithPassword(password string) Option { return func(o *options) { o.password = password } }
+
+func newOptions(opts []Option) (options, error) {
+	o := options{version: DefaultVersion}
+	for _, f := range opts {
+		f(&o)
+	}
+	switch o.version {
+	case Version12, Version13:
+	default:
+		return o, fmt.Errorf("unsupported ODF version %q", o.version)
+	}
+	return o, nil
+}
+
+// WithValidation makes Close check the written package with Validate - for debugging,
+// as it keeps a copy of the output in a temporary file.
+func WithValidation(validate bool) Option { return func(o *options) { o.validate = validate } }
+
 // NewWriter returns a content writer and a zip closer for an ods file.
 //
 // This writer allows concurrent write to separate sheets.
-func NewWriter(w io.Writer) (*ODSWriter, error) {
+func NewWriter(w io.Writer, opts ...Option) (*ODSWriter, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	ow := &ODSWriter{version: o.version, password: o.password}
+	if o.validate {
+		var copyFile *os.File
+		if copyFile, err = os.CreateTemp("", "spreadsheet-ods-*.ods"); err != nil {
+			return nil, err
+		}
+		os.Remove(copyFile.Name())
+		w = io.MultiWriter(w, copyFile)
+		ow.copyFile = copyFile
+	}
 	now := time.Now()
 	zw := zip.NewWriter(w)
+	if err := writeMimetype(zw, now); err != nil {
+		return nil, err
+	}
+	if ow.password != "" {
+		// the parts are stored at Close, followed by the manifest with the encryption data
+		for _, elt := range []struct {
+			Stream func(*qt.Writer)
+			Name   string
+		}{
+			{Name: "meta.xml", Stream: ow.StreamMeta},
+			{Name: "settings.xml", Stream: ow.StreamSettings},
+			{Name: "content.xml", Stream: func(*qt.Writer) {}},
+		} {
+			ep, err := newEncryptedPart(elt.Name, ow.password)
+			if err != nil {
+				ow.closeEncrypted()
+				return nil, err
+			}
+			ow.encrypted = append(ow.encrypted, ep)
+			W := acquireWriter(ep)
+			elt.Stream(W)
+			releaseWriter(W)
+		}
+		ow.w, ow.zipWriter = ow.encrypted[len(ow.encrypted)-1], zw
+		ow.head = ow.StreamBeginSpreadsheet
+		return ow, nil
+	}
 	for _, elt := range []struct {
 		Stream func(*qt.Writer)
 		Name   string
 	}{
-		{Name: "mimetype", Stream: StreamMimetype},
-		{Name: "meta.xml", Stream: StreamMeta},
-		{Name: "META-INF/manifest.xml", Stream: StreamManifest},
-		{Name: "settings.xml", Stream: StreamSettings},
+		{Name: "meta.xml", Stream: ow.StreamMeta},
+		{Name: "META-INF/manifest.xml", Stream: ow.StreamManifest},
+		{Name: "settings.xml", Stream: ow.StreamSettings},
 	} {
 		parts := strings.SplitAfter(elt.Name, "/")
 		var prev string
@@ -126,20 +244,96 @@ func NewWriter(w io.Writer) (*ODSWriter, error) {
 		zw.Close()
 		return nil, err
 	}
-	W := acquireWriter(bw)
-	StreamBeginSpreadsheet(W)
-	releaseWriter(W)
+	ow.w, ow.zipWriter = bw, zw
+	ow.head = ow.StreamBeginSpreadsheet
+	return ow, nil
+}
 
-	return &ODSWriter{w: bw, zipWriter: zw}, nil
+// writeMimetype writes the mimetype entry, as the ODF requires: first, stored uncompressed,
+// without extra field and data descriptor, so the media type is readable at offset 38.
+func writeMimetype(zw *zip.Writer, now time.Time) error {
+	mimetype := []byte(Mimetype())
+	fh := zip.FileHeader{
+		Name: "mimetype", Method: zip.Store,
+		CRC32:              crc32.ChecksumIEEE(mimetype),
+		CompressedSize64:   uint64(len(mimetype)),
+		UncompressedSize64: uint64(len(mimetype)),
+	}
+	// not Modified, as that would add an extended timestamp extra field
+	fh.ModifiedDate = uint16(now.Day() + int(now.Month())<<5 + (now.Year()-1980)<<9)
+	fh.ModifiedTime = uint16(now.Second()/2 + now.Minute()<<5 + now.Hour()<<11)
+	w, err := zw.CreateRaw(&fh)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(mimetype)
+	return err
+}
+
+// NewFlatWriter returns a writer for a flat XML (.fods) file:
+// one uncompressed XML document, containing the meta, settings, styles and content.
+//
+// As the styles precede the content, the content is buffered in a temporary file,
+// and written when the writer is closed.
+//
+// The WithValidation option is ignored, as Validate checks packages only,
+// and WithPassword is an error.
+//
+// This writer allows concurrent write to separate sheets.
+func NewFlatWriter(w io.Writer, opts ...Option) (*ODSWriter, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	if o.password != "" {
+		return nil, errors.New("the flat XML can not be encrypted")
+	}
+	sp := spool.New(w)
+	head, err := sp.NewPart(nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := sp.NewPart(nil)
+	if err != nil {
+		head.Close()
+		return nil, err
+	}
+	ow := &ODSWriter{w: body, version: o.version, flat: &flatParts{spool: sp, head: head, body: body}}
+	ow.head = ow.StreamBeginBody
+	return ow, nil
 }
 
 // ODSWriter writes content.xml of ODS zip.
 type ODSWriter struct {
 	w         io.Writer
 	zipWriter *zip.Writer
+	flat      *flatParts
+	version   string
 	styles    map[string]string
-	files     []<-chan io.ReadCloser
-	mu        sync.Mutex
+	copyFile  *os.File
+	password  string
+	encrypted []*encryptedPart
+	// head writes the beginning of the content at the first NewSheet,
+	// or at Close if the first sheet has validations, as the head contains them.
+	head               func(*qt.Writer)
+	sheetNames         []string
+	protections        map[string]spreadsheet.SheetProtection
+	workbookProtection *string
+	validations        []string
+	files              []<-chan io.ReadCloser
+	mu                 sync.Mutex
+
+	// conditionStyles are the common styles applied by the conditional formats
+	conditionStyles map[string]string
+	// stylesMu guards styles and conditionStyles, as the sheets add styles while appending rows,
+	// without mu, which Close holds while waiting for the sheets
+	stylesMu sync.Mutex
+}
+
+// flatParts are the parts of the flat XML document.
+type flatParts struct {
+	spool      *spool.Spool
+	head, body *spool.Part
 }
 
 // Close the ODSWriter.
@@ -152,12 +346,32 @@ func (ow *ODSWriter) Close() error {
 	if ow.w == nil {
 		return nil
 	}
+	ow.writeHead()
 
 	if err := ow.copyFiles(true); err != nil {
 		return err
 	}
 	ow.files = nil
 
+	if flat := ow.flat; flat != nil {
+		ow.w, ow.flat = nil, nil
+		W := acquireWriter(flat.body)
+		StreamEndFlat(W)
+		releaseWriter(W)
+		W = acquireWriter(flat.head)
+		ow.stylesMu.Lock()
+		ow.StreamBeginFlat(W, ow.styles)
+		ow.stylesMu.Unlock()
+		releaseWriter(W)
+		if err := flat.head.Close(); err != nil {
+			return err
+		}
+		if err := flat.body.Close(); err != nil {
+			return err
+		}
+		return flat.spool.Close()
+	}
+
 	W := acquireWriter(ow.w)
 	StreamEndSpreadsheet(W)
 	releaseWriter(W)
@@ -165,20 +379,136 @@ func (ow *ODSWriter) Close() error {
 	zw := ow.zipWriter
 	ow.zipWriter = nil
 	defer zw.Close()
-	bw, err := zw.CreateHeader(&zip.FileHeader{
-		Name: "styles.xml", Method: zip.Deflate, Modified: time.Now(),
+	if ow.encrypted != nil {
+		if err := ow.finishEncrypted(zw); err != nil {
+			return err
+		}
+	} else {
+		bw, err := zw.CreateHeader(&zip.FileHeader{
+			Name: "styles.xml", Method: zip.Deflate, Modified: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+		W = acquireWriter(bw)
+		ow.stylesMu.Lock()
+		ow.StreamStyles(W, ow.styles)
+		ow.stylesMu.Unlock()
+		releaseWriter(W)
+	}
+	if err := zw.Close(); err != nil || ow.copyFile == nil {
+		return err
+	}
+	f := ow.copyFile
+	ow.copyFile = nil
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return Validate(f, fi.Size())
+}
+
+// finishEncrypted encrypts the styles, stores the encrypted parts in the zip,
+// then writes the manifest.
+func (ow *ODSWriter) finishEncrypted(zw *zip.Writer) error {
+	defer ow.closeEncrypted()
+	ep, err := newEncryptedPart("styles.xml", ow.password)
+	if err != nil {
+		return err
+	}
+	ow.encrypted = append(ow.encrypted, ep)
+	W := acquireWriter(ep)
+	ow.stylesMu.Lock()
+	ow.StreamStyles(W, ow.styles)
+	ow.stylesMu.Unlock()
+	releaseWriter(W)
+	now := time.Now()
+	for _, ep := range ow.encrypted {
+		if err := ep.finish(); err != nil {
+			return err
+		}
+		if err := ep.copyTo(zw, now); err != nil {
+			return err
+		}
+	}
+	w, err := zw.CreateHeader(&zip.FileHeader{
+		Name: "META-INF/manifest.xml", Method: zip.Deflate, Modified: now,
 	})
 	if err != nil {
 		return err
 	}
-	W = acquireWriter(bw)
-	StreamStyles(W, ow.styles)
+	W = acquireWriter(w)
+	ow.StreamManifest(W)
 	releaseWriter(W)
-	return zw.Close()
+	return nil
 }
 
-// copyFiles copies the finished files.
+// closeEncrypted closes the temporary files of the encrypted parts.
+func (ow *ODSWriter) closeEncrypted() {
+	for _, ep := range ow.encrypted {
+		ep.f.Close()
+	}
+}
+
+// encryption returns the encrypted part with the name, or nil.
+func (ow *ODSWriter) encryption(name string) *encryptedPart {
+	for _, ep := range ow.encrypted {
+		if ep.name == name {
+			return ep
+		}
+	}
+	return nil
+}
+
+// writeHead writes the beginning of the content, if not written yet.
+func (ow *ODSWriter) writeHead() {
+	if ow.head == nil {
+		return
+	}
+	W := acquireWriter(ow.w)
+	ow.head(W)
+	releaseWriter(W)
+	ow.head = nil
+}
+
+// ProtectWorkbook protects the structure of the workbook.
+// It must be called before the first NewSheet.
+func (ow *ODSWriter) ProtectWorkbook(password string) error {
+	ow.mu.Lock()
+	defer ow.mu.Unlock()
+	if len(ow.sheetNames) != 0 {
+		return errors.New("ProtectWorkbook must be called before NewSheet")
+	}
+	ow.workbookProtection = &password
+	return nil
+}
+
+// ProtectSheet protects the named sheet. It must be called before the NewSheet creating the sheet.
+//
+// AllowSort and AllowFilter are not supported, and the allowed insertions and deletions
+// are written only with the LibreOffice extensions (ODF 1.2).
+func (ow *ODSWriter) ProtectSheet(name string, p spreadsheet.SheetProtection) error {
+	ow.mu.Lock()
+	defer ow.mu.Unlock()
+	if slices.Contains(ow.sheetNames, name) {
+		return fmt.Errorf("ProtectSheet(%q) must be called before NewSheet", name)
+	}
+	if ow.protections == nil {
+		ow.protections = make(map[string]spreadsheet.SheetProtection)
+	}
+	ow.protections[name] = p
+	return nil
+}
+
+// extended reports whether the LibreOffice extensions are used.
+func (ow *ODSWriter) extended() bool { return ow.version == Version12 }
+
+// copyFiles copies the finished files, after the head has been written.
 func (ow *ODSWriter) copyFiles(wait bool) error {
+	if ow.head != nil {
+		return nil
+	}
 	for _, ch := range ow.files {
 		var f io.ReadCloser
 		if wait {
@@ -202,10 +532,50 @@ func (ow *ODSWriter) copyFiles(wait bool) error {
 	return nil
 }
 
+// NewSheet starts a new sheet.
+//
+// The content validations precede the sheets, so if the columns of the first sheet
+// have Validations, the sheets are kept in temporary files till Close, and later sheets
+// can have Validations, too. Otherwise the sheets are streamed to the output as they
+// are closed, and the later sheets' Validations return ErrInvalidValidation.
 func (ow *ODSWriter) NewSheet(name string, cols []spreadsheet.Column) (spreadsheet.Sheet, error) {
+	var hasValidation bool
+	for _, c := range cols {
+		if err := c.Check(); err != nil {
+			return nil, err
+		}
+		hasValidation = hasValidation || c.Validation != nil
+	}
 	ow.mu.Lock()
 	defer ow.mu.Unlock()
-	sheet := &ODSSheet{Name: name, ow: ow}
+	if hasValidation && ow.head == nil {
+		return nil, fmt.Errorf("%s: %w: the validations must be set on the first sheet's columns", name, spreadsheet.ErrInvalidValidation)
+	}
+	sheet := &ODSSheet{Name: name, ow: ow, columnStyles: make([]spreadsheet.Style, len(cols))}
+	for i, c := range cols {
+		sheet.columnStyles[i] = c.Column
+	}
+	headerRows := len(header.Rows(cols))
+	firstRow := headerRows + 1
+	nValidations := len(ow.validations)
+	for i, c := range cols {
+		if c.Validation == nil {
+			continue
+		}
+		if sheet.validations == nil {
+			sheet.validations = make([]string, len(cols))
+		}
+		var err error
+		if sheet.validations[i], err = ow.addValidation(name, i, firstRow, *c.Validation); err != nil {
+			// drop the earlier columns' validations of the failed sheet
+			ow.validations = ow.validations[:nValidations]
+			return nil, fmt.Errorf("%s[%s]: %w", name, spreadsheet.ColumnName(i), err)
+		}
+	}
+	ow.sheetNames = append(ow.sheetNames, name)
+	if len(ow.validations) == 0 {
+		ow.writeHead()
+	}
 
 	var err error
 	if sheet.f, err = os.CreateTemp("", "spreadsheet-ods-*.xml"); err != nil {
@@ -222,27 +592,67 @@ func (ow *ODSWriter) NewSheet(name string, cols []spreadsheet.Column) (spreadshe
 	ow.files = append(ow.files, ch)
 
 	ow.StreamBeginSheet(sheet.w, name, cols)
+	sheet.rowCount = headerRows // the header rows count, too
 	return sheet, nil
 }
 
 func (ow *ODSWriter) getStyleName(style spreadsheet.Style) string {
-	if !style.FontBold {
+	props := styleProperties(style)
+	if props == "" {
 		return ""
 	}
+	return ow.addStyle(props)
+}
+
+// addStyle adds the automatic cell style with the properties (and maps), and returns its name.
+func (ow *ODSWriter) addStyle(props string) string {
 	hsh := fnv.New32()
-	//fmt.Fprintf(hsh, "%t\t%s", style.FontBold, style.Format)
-	fmt.Fprintf(hsh, "%t", style.FontBold)
-	k := fmt.Sprintf("bf-%d", hsh.Sum32())
+	hsh.Write([]byte(props))
+	k := fmt.Sprintf("ce-%d", hsh.Sum32())
+	ow.stylesMu.Lock()
+	defer ow.stylesMu.Unlock()
 	if _, ok := ow.styles[k]; ok {
 		return k
 	}
 	if ow.styles == nil {
 		ow.styles = make(map[string]string, 1)
 	}
-	ow.styles[k] = `<style:style style:name="` + k + `" style:family="table-cell"><style:text-properties text:display="true" fo:font-weight="bold" /></style:style>`
+	ow.styles[k] = `<style:style style:name="` + k + `" style:family="table-cell">` + props + `</style:style>`
 	return k
 }
 
+// styleProperties returns the cell and text properties of the style.
+func styleProperties(style spreadsheet.Style) string {
+	var cell, text string
+	if style.Unlocked {
+		cell += ` style:cell-protect="none"`
+	}
+	if style.BackgroundColor != "" {
+		cell += ` fo:background-color="` + attrEscape(style.BackgroundColor) + `"`
+	}
+	if style.FontBold {
+		text += ` fo:font-weight="bold"`
+	}
+	if style.FontColor != "" {
+		text += ` fo:color="` + attrEscape(style.FontColor) + `"`
+	}
+	var props string
+	if cell != "" {
+		props += `<style:table-cell-properties` + cell + ` />`
+	}
+	if text != "" {
+		props += `<style:text-properties text:display="true"` + text + ` />`
+	}
+	return props
+}
+
+// attrEscape escapes the attribute value.
+func attrEscape(s string) string {
+	var buf strings.Builder
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
 type ODSSheet struct {
 	done     chan<- io.ReadCloser
 	ow       *ODSWriter
@@ -252,6 +662,17 @@ type ODSSheet struct {
 	Name     string
 	rowCount int
 	mu       sync.Mutex
+
+	// validations are the content validation names of the columns
+	validations []string
+	// columnStyles are the styles of the columns, for the conditional cell styles
+	columnStyles []spreadsheet.Style
+	conditions   []conditionalFormat
+	// cellStyles caches the conditional cell styles
+	cellStyles map[string]string
+	merges     []cellRange
+	// mergedColumns is the number of the columns up to the last merged column
+	mergedColumns int
 }
 
 const MaxRowCount = 1 << 20
@@ -262,7 +683,7 @@ func (ods *ODSSheet) AppendRow(values ...any) error {
 		ods.mu.Unlock()
 		return spreadsheet.ErrTooManyRows
 	}
-	StreamRow(ods.w, values...)
+	ods.StreamRow(ods.w, values...)
 	ods.rowCount++
 	ods.mu.Unlock()
 	return nil
@@ -275,12 +696,13 @@ func (ods *ODSSheet) Close() error {
 	ods.mu.Lock()
 	defer ods.mu.Unlock()
 
-	ow, W, zw, f, done := ods.ow, ods.w, ods.zw, ods.f, ods.done
-	ods.ow, ods.w, ods.zw, ods.f, ods.done = nil, nil, nil, nil, nil
-	if W == nil {
+	if ods.w == nil {
 		return nil
 	}
-	StreamEndSheet(W)
+	ods.appendMerged()
+	ods.StreamEndSheet(ods.w)
+	ow, W, zw, f, done := ods.ow, ods.w, ods.zw, ods.f, ods.done
+	ods.ow, ods.w, ods.zw, ods.f, ods.done = nil, nil, nil, nil, nil
 	releaseWriter(W)
 	if done == nil {
 		return nil
@@ -0,0 +1,1005 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ods_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zip"
+
+	"github.com/UNO-SOFT/spreadsheet"
+	"github.com/UNO-SOFT/spreadsheet/ods"
+	"github.com/UNO-SOFT/spreadsheet/spreadsheettest"
+)
+
+func TestConformance(t *testing.T) {
+	spreadsheettest.Run(t, spreadsheettest.Config{
+		New: func(w io.Writer) (spreadsheet.Writer, error) { return ods.NewWriter(w) },
+		Read: func(b []byte) (spreadsheet.Reader, error) {
+			return ods.NewReader(bytes.NewReader(b), int64(len(b)))
+		},
+		MaxRows: ods.MaxRowCount,
+		Style:   cellStyle,
+	})
+}
+
+const (
+	nsStyle = "urn:oasis:names:tc:opendocument:xmlns:style:1.0"
+	nsTable = "urn:oasis:names:tc:opendocument:xmlns:table:1.0"
+	nsFO    = "urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0"
+)
+
+// cellStyle returns the style of the cell: the cell's style, or its column's default cell style.
+func cellStyle(data []byte, sheet string, row, col int) (spreadsheet.Style, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return spreadsheet.Style{}, err
+	}
+	styles := make(map[string]spreadsheet.Style)
+	var styleName string
+	for _, fn := range []string{"styles.xml", "content.xml"} {
+		var name string
+		var columns []string
+		var inSheet, inRow bool
+		r, c := -1, 0
+		if err = walkXML(zr, fn, func(se xml.StartElement) bool {
+			switch se.Name {
+			case xml.Name{Space: nsStyle, Local: "style"}:
+				name = attrValue(se, nsStyle, "name")
+			case xml.Name{Space: nsStyle, Local: "text-properties"}:
+				st := styles[name]
+				st.FontBold = attrValue(se, nsFO, "font-weight") == "bold"
+				st.FontColor = attrValue(se, nsFO, "color")
+				styles[name] = st
+			case xml.Name{Space: nsStyle, Local: "table-cell-properties"}:
+				st := styles[name]
+				st.BackgroundColor = attrValue(se, nsFO, "background-color")
+				styles[name] = st
+			case xml.Name{Space: nsTable, Local: "table"}:
+				inSheet = attrValue(se, nsTable, "name") == sheet
+			case xml.Name{Space: nsTable, Local: "table-column"}:
+				if inSheet {
+					for range min(repeated(se, "number-columns-repeated"), col+1) {
+						columns = append(columns, attrValue(se, nsTable, "default-cell-style-name"))
+					}
+				}
+			case xml.Name{Space: nsTable, Local: "table-row"}:
+				if inSheet {
+					n := repeated(se, "number-rows-repeated")
+					inRow, r, c = r < row && row <= r+n, r+n, 0
+				}
+			case xml.Name{Space: nsTable, Local: "table-cell"}, xml.Name{Space: nsTable, Local: "covered-table-cell"}:
+				if !inRow {
+					break
+				}
+				n := repeated(se, "number-columns-repeated")
+				if c <= col && col < c+n {
+					if styleName = attrValue(se, nsTable, "style-name"); styleName == "" && col < len(columns) {
+						styleName = columns[col]
+					}
+					return false
+				}
+				c += n
+			}
+			return true
+		}); err != nil {
+			return spreadsheet.Style{}, err
+		}
+	}
+	return styles[styleName], nil
+}
+
+// walkXML calls fn with the start elements of the file in the zip, till fn returns false.
+func walkXML(zr *zip.Reader, name string, fn func(xml.StartElement) bool) error {
+	rc, err := zr.Open(name)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	dec := xml.NewDecoder(rc)
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if se, ok := tok.(xml.StartElement); ok && !fn(se) {
+			return nil
+		}
+	}
+}
+
+func attrValue(se xml.StartElement, space, local string) string {
+	for _, a := range se.Attr {
+		if a.Name.Space == space && a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+func repeated(se xml.StartElement, local string) int {
+	if n, err := strconv.Atoi(attrValue(se, nsTable, local)); err == nil && n > 0 {
+		return n
+	}
+	return 1
+}
+
+func TestValidate(t *testing.T) {
+	b, err := os.ReadFile(filepath.Join("testdata", "2sheets.ods"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Gnumeric references its "General" data style without defining it
+	err = ods.Validate(bytes.NewReader(b), int64(len(b)))
+	for _, err := range err.(interface{ Unwrap() []error }).Unwrap() {
+		if !strings.Contains(err.Error(), `undefined style "General" referenced in style/@data-style-name`) {
+			t.Errorf("2sheets.ods: %+v", err)
+		}
+	}
+}
+
+func TestValidateBad(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range []struct {
+		Name, Content string
+		Method        uint16
+	}{
+		{Name: "content.xml", Method: zip.Deflate, Content: `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0">
+<office:body><office:spreadsheet><table:table table:name="A"><table:table-column table:style-name="co1"/></table:table></office:spreadsheet></office:body>
+</office:document-content>`},
+		{Name: "mimetype", Method: zip.Deflate, Content: "application/vnd.oasis.opendocument.spreadsheet"},
+		{Name: "styles.xml", Method: zip.Deflate, Content: `<office:document-styles><unclosed>`},
+		{Name: "extra.xml", Method: zip.Deflate, Content: `<a/>`},
+		{Name: "META-INF/manifest.xml", Method: zip.Deflate, Content: `<?xml version="1.0" encoding="UTF-8"?>
+<manifest:manifest xmlns:manifest="urn:oasis:names:tc:opendocument:xmlns:manifest:1.0" manifest:version="1.2">
+  <manifest:file-entry manifest:media-type="application/vnd.oasis.opendocument.spreadsheet" manifest:full-path="/"/>
+  <manifest:file-entry manifest:media-type="text/xml" manifest:full-path="content.xml"/>
+  <manifest:file-entry manifest:media-type="text/xml" manifest:full-path="styles.xml"/>
+  <manifest:file-entry manifest:media-type="text/xml" manifest:full-path="meta.xml"/>
+</manifest:manifest>`},
+	} {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: f.Name, Method: f.Method})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = io.WriteString(w, f.Content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	err := ods.Validate(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if !errors.Is(err, ods.ErrInvalid) {
+		t.Fatalf("got %+v, wanted ErrInvalid", err)
+	}
+	t.Log(err)
+	got := make(map[string]string)
+	for _, err := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var ve *ods.ValidationError
+		if !errors.As(err, &ve) {
+			t.Fatalf("%+v is not a ValidationError", err)
+		}
+		got[ve.Part] += ve.Err.Error() + "\n"
+	}
+	for part, want := range map[string]string{
+		"mimetype":              "not the first entry",
+		"META-INF/manifest.xml": `"meta.xml"`,
+		"styles.xml":            "unexpected EOF",
+		"extra.xml":             "not listed",
+		"content.xml":           `undefined style "co1"`,
+	} {
+		if !strings.Contains(got[part], want) {
+			t.Errorf("%s: got %q, wanted %q", part, got[part], want)
+		}
+	}
+}
+
+func TestMimetype(t *testing.T) {
+	b := writeSample(t, ods.WithValidation(true))
+	const mimetype = "application/vnd.oasis.opendocument.spreadsheet"
+	if got := string(b[:4]); got != "PK\x03\x04" {
+		t.Errorf("got %q, wanted a local file header", got)
+	}
+	if got := string(b[30:38]); got != "mimetype" {
+		t.Errorf("got %q at 30, wanted mimetype", got)
+	}
+	if got := string(b[38 : 38+len(mimetype)]); got != mimetype {
+		t.Errorf("got %q at 38, wanted %q", got, mimetype)
+	}
+	if err := ods.Validate(bytes.NewReader(b), int64(len(b))); err != nil {
+		t.Errorf("%+v", err)
+	}
+}
+
+func TestVersion(t *testing.T) {
+	for _, version := range []string{ods.Version12, ods.Version13} {
+		t.Run(version, func(t *testing.T) {
+			b := writeSample(t, ods.WithVersion(version), ods.WithValidation(true))
+			zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, f := range zr.File {
+				if !strings.HasSuffix(f.Name, ".xml") {
+					continue
+				}
+				rc, err := f.Open()
+				if err != nil {
+					t.Fatal(err)
+				}
+				xml, err := io.ReadAll(rc)
+				rc.Close()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Contains(xml, []byte(`:version="`+version+`"`)) {
+					t.Errorf("%s: no version %q", f.Name, version)
+				}
+				// the 1.2 output marks the numbers with calcext:value-type, too
+				if hasExt := bytes.Contains(xml, []byte("calcext")); hasExt && version != ods.Version12 ||
+					!hasExt && f.Name == "content.xml" && version == ods.Version12 {
+					t.Errorf("%s: calcext is used: %t", f.Name, hasExt)
+				}
+			}
+		})
+	}
+
+	if _, err := ods.NewWriter(io.Discard, ods.WithVersion("1.1")); err == nil {
+		t.Error("version 1.1 is accepted")
+	}
+}
+
+func writeSample(t *testing.T, opts ...ods.Option) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := ods.NewWriter(&buf, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sheet, err := w.NewSheet("Sheet1", []spreadsheet.Column{
+		{Name: "bold", Header: spreadsheet.Style{FontBold: true}}, {Name: "plain"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = sheet.AppendRow(1, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if err = sheet.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestEncryption(t *testing.T) {
+	const password = "Titkos jelszó"
+	b := writeSample(t, ods.WithPassword(password), ods.WithValidation(true))
+	if bytes.Contains(b, []byte("plain")) {
+		t.Error("plain text in the encrypted output")
+	}
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var dec bytes.Buffer
+	zw := zip.NewWriter(&dec)
+	entries := readManifest(t, zr)
+	for _, f := range zr.File {
+		e, ok := entries[f.Name]
+		if !ok || e.Encryption == nil {
+			continue
+		}
+		if _, err := decryptPart(f, e, password+"!"); err == nil {
+			t.Errorf("%s: decrypted with a bad password", f.Name)
+		}
+		data, err := decryptPart(f, e, password)
+		if err != nil {
+			t.Fatalf("%s: %+v", f.Name, err)
+		}
+		w, err := zw.Create(f.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(data)
+	}
+	if err = zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := ods.NewReader(bytes.NewReader(dec.Bytes()), int64(dec.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got [][]string
+	if err = r.ReadRows(context.Background(), "Sheet1", func(row []string) error {
+		got = append(got, slices.Clone(row))
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if want := [][]string{{"bold", "plain"}, {"1", "a"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+type manifestEntry struct {
+	FullPath   string `xml:"full-path,attr"`
+	Size       int    `xml:"size,attr"`
+	Encryption *struct {
+		ChecksumType string `xml:"checksum-type,attr"`
+		Checksum     []byte `xml:"checksum,attr"`
+		Algorithm    struct {
+			Name string `xml:"algorithm-name,attr"`
+			IV   []byte `xml:"initialisation-vector,attr"`
+		} `xml:"algorithm"`
+		StartKey struct {
+			Name string `xml:"start-key-generation-name,attr"`
+		} `xml:"start-key-generation"`
+		KeyDerivation struct {
+			Name      string `xml:"key-derivation-name,attr"`
+			KeySize   int    `xml:"key-size,attr"`
+			IterCount int    `xml:"iteration-count,attr"`
+			Salt      []byte `xml:"salt,attr"`
+		} `xml:"key-derivation"`
+	} `xml:"encryption-data"`
+}
+
+func readManifest(t *testing.T, zr *zip.Reader) map[string]manifestEntry {
+	t.Helper()
+	rc, err := zr.Open("META-INF/manifest.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	var manifest struct {
+		Entries []manifestEntry `xml:"file-entry"`
+	}
+	if err = xml.NewDecoder(rc).Decode(&manifest); err != nil {
+		t.Fatal(err)
+	}
+	entries := make(map[string]manifestEntry, len(manifest.Entries))
+	for _, e := range manifest.Entries {
+		if enc := e.Encryption; enc != nil {
+			// the base64 attributes
+			for _, p := range []*[]byte{&enc.Checksum, &enc.Algorithm.IV, &enc.KeyDerivation.Salt} {
+				if *p, err = base64.StdEncoding.AppendDecode(nil, *p); err != nil {
+					t.Fatalf("%s: %+v", e.FullPath, err)
+				}
+			}
+		}
+		entries[e.FullPath] = e
+	}
+	return entries
+}
+
+// decryptPart decrypts the part as an ODF consumer does.
+func decryptPart(f *zip.File, e manifestEntry, password string) ([]byte, error) {
+	enc := e.Encryption
+	if enc.Algorithm.Name != "http://www.w3.org/2001/04/xmlenc#aes256-cbc" ||
+		enc.StartKey.Name != "http://www.w3.org/2000/09/xmldsig#sha256" ||
+		enc.KeyDerivation.Name != "PBKDF2" ||
+		enc.ChecksumType != "urn:oasis:names:tc:opendocument:xmlns:manifest:1.0#sha256-1k" {
+		return nil, fmt.Errorf("unknown encryption %+v", enc)
+	}
+	if f.Method != zip.Store {
+		return nil, fmt.Errorf("method is %d", f.Method)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 || len(data)%aes.BlockSize != 0 {
+		return nil, fmt.Errorf("length %d is not a multiple of the block size", len(data))
+	}
+	startKey := sha256.Sum256([]byte(password))
+	key, err := pbkdf2.Key(sha1.New, string(startKey[:]), enc.KeyDerivation.Salt,
+		enc.KeyDerivation.IterCount, enc.KeyDerivation.KeySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	cipher.NewCBCDecrypter(block, enc.Algorithm.IV).CryptBlocks(data, data)
+	if n := int(data[len(data)-1]); n == 0 || n > aes.BlockSize {
+		return nil, fmt.Errorf("bad padding %d", n)
+	} else {
+		data = data[:len(data)-n]
+	}
+	if sum := sha256.Sum256(data[:min(len(data), 1024)]); !bytes.Equal(sum[:], enc.Checksum) {
+		return nil, errors.New("checksum mismatch")
+	}
+	if data, err = io.ReadAll(flate.NewReader(bytes.NewReader(data))); err != nil {
+		return nil, err
+	}
+	if len(data) != e.Size {
+		return nil, fmt.Errorf("got %d bytes, manifest says %d", len(data), e.Size)
+	}
+	return data, nil
+}
+
+func TestProtection(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := ods.NewWriter(&buf, ods.WithValidation(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = w.ProtectWorkbook("secret"); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.ProtectSheet("Protected", spreadsheet.SheetProtection{Password: "secret", AllowInsertRows: true}); err != nil {
+		t.Fatal(err)
+	}
+	sheet, err := w.NewSheet("Protected", []spreadsheet.Column{
+		{Name: "input", Column: spreadsheet.Style{Unlocked: true}}, {Name: "fixed"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = sheet.AppendRow("x", 1); err != nil {
+		t.Fatal(err)
+	}
+	if err = sheet.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.ProtectWorkbook("late"); err == nil {
+		t.Error("ProtectWorkbook succeeded after NewSheet")
+	}
+	if err = w.ProtectSheet("Protected", spreadsheet.SheetProtection{}); err == nil {
+		t.Error("ProtectSheet succeeded after NewSheet")
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// base64(sha256("secret"))
+	const key = `table:protection-key="K7gNU3sdo+OL0wNhqoVWhr3g6s1xYv72ol/pe/Unols=" table:protection-key-digest-algorithm="http://www.w3.org/2000/09/xmldsig#sha256"`
+	for name, wants := range map[string][]string{
+		"content.xml": {
+			`<office:spreadsheet table:structure-protected="true" ` + key + `>`,
+			`table:name="Protected" table:print="true" table:protected="true" ` + key + `>`,
+			`loext:insert-rows="true"`,
+			`table:default-cell-style-name="ce-`,
+		},
+		"styles.xml": {`style:cell-protect="none"`},
+	} {
+		rc, err := zr.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range wants {
+			if !bytes.Contains(b, []byte(want)) {
+				t.Errorf("%s: no %s", name, want)
+			}
+		}
+	}
+}
+
+func TestDataValidation(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := ods.NewWriter(&buf, ods.WithValidation(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = w.NewSheet("Bad", []spreadsheet.Column{
+		{Name: "ok", Validation: &spreadsheet.Validation{Type: spreadsheet.ValidateWhole, Min: 1, Max: 2}},
+		{Name: "a", Validation: &spreadsheet.Validation{Type: spreadsheet.ValidateList}},
+	}); !errors.Is(err, spreadsheet.ErrInvalidValidation) {
+		t.Errorf("got %+v, wanted ErrInvalidValidation", err)
+	}
+	sheet, err := w.NewSheet("Input", []spreadsheet.Column{
+		{Name: "choice", Validation: &spreadsheet.Validation{
+			Type: spreadsheet.ValidateList, List: []string{"yes", `"no"`},
+			ErrorTitle: "Invalid", ErrorMessage: "Choose\nfrom the list",
+		}},
+		{Name: "free"},
+		{Name: "count", Validation: &spreadsheet.Validation{Type: spreadsheet.ValidateWhole, Min: 1, Max: 10}},
+		{Name: "code", Validation: &spreadsheet.Validation{Type: spreadsheet.ValidateTextLength, Max: 8}},
+		{Name: "listed", Validation: &spreadsheet.Validation{Type: spreadsheet.ValidateList, ListRange: "'Code lists'!$A$2:$A$3"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = sheet.AppendRow("yes", "x"); err != nil {
+		t.Fatal(err)
+	}
+	if err = sheet.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rc, err := zr.Open("content.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`<table:content-validations>`,
+		`table:condition="of:cell-content-is-in-list(&#34;yes&#34;;&#34;&#34;&#34;no&#34;&#34;&#34;)"`,
+		`table:display-list="unsorted" table:base-cell-address="&#39;Input&#39;.A2"`,
+		`table:title="Invalid"><text:p>Choose</text:p><text:p>from the list</text:p>`,
+		`table:condition="of:cell-content-is-whole-number() and cell-content-is-between(1;10)"`,
+		`table:condition="of:cell-content-text-length()&lt;=8"`,
+		`table:condition="of:cell-content-is-in-list([$&#39;Code lists&#39;.$A$2:.$A$3])"`,
+		`table:content-validation-name="val1"`,
+		`table:number-rows-repeated="1048574"`,
+	} {
+		if !bytes.Contains(b, []byte(want)) {
+			t.Errorf("no %s", want)
+		}
+	}
+	if bytes.Contains(b, []byte(`table:content-validation-name="val0"`)) {
+		t.Error("the invalid validation is referenced")
+	}
+	if n := bytes.Count(b, []byte("<table:content-validation ")); n != 4 {
+		t.Errorf("got %d validations, wanted the 4 of the Input sheet", n)
+	}
+	if bytes.Contains(b, []byte(`cell-content-is-between(1;2)`)) {
+		t.Error("the validation of the failed sheet is kept")
+	}
+	if err = ods.Validate(bytes.NewReader(buf.Bytes()), int64(buf.Len())); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStreaming(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := ods.NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	sheet, err := w.NewSheet("Streamed", []spreadsheet.Column{{Name: "a"}, {Name: "b"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := buf.Len()
+	for i := range 10000 {
+		if err = sheet.AppendRow(i, strconv.FormatUint(uint64(i)*2654435761, 36)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = sheet.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() <= n {
+		t.Errorf("the closed sheet is not streamed before Close: %d bytes", buf.Len())
+	}
+	late, err := w.NewSheet("Late", []spreadsheet.Column{{Name: "a", Validation: &spreadsheet.Validation{
+		Type: spreadsheet.ValidateWhole, Min: 1,
+	}}})
+	if err == nil {
+		late.Close()
+	}
+	if !errors.Is(err, spreadsheet.ErrInvalidValidation) {
+		t.Errorf("got %+v, wanted ErrInvalidValidation for a validation after the head", err)
+	}
+}
+
+func TestConditionalFormat(t *testing.T) {
+	for _, version := range []string{ods.Version12, ods.Version13} {
+		t.Run(version, func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := ods.NewWriter(&buf, ods.WithVersion(version), ods.WithValidation(true))
+			if err != nil {
+				t.Fatal(err)
+			}
+			sheet, err := w.NewSheet("Due's", []spreadsheet.Column{
+				{Name: "amount", Column: spreadsheet.Style{FontColor: "#000080"}}, {Name: "due"},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			cf := sheet.(spreadsheet.ConditionalFormatter)
+			for ref, c := range map[string]spreadsheet.ConditionalFormat{
+				"A2:A10": {
+					Type: spreadsheet.ConditionCellValue, Operator: spreadsheet.OperatorBetween, Value: "-10", Value2: "$B$1",
+					Style: spreadsheet.Style{FontColor: "#C00000", BackgroundColor: "#FFC7CE"},
+				},
+				"B2:B10": {
+					Type: spreadsheet.ConditionFormula, Formula: `AND(B2<>"a,b", B2<TODAY(), 'Other sheet'!$A$1:B2)`,
+					Style: spreadsheet.Style{FontBold: true},
+				},
+				"A2:A3": {Type: spreadsheet.ConditionDataBar, BarColor: "#638EC6"},
+			} {
+				if err = cf.AddConditionalFormat(ref, c); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err = sheet.AppendRow(-1, time.Now()); err != nil {
+				t.Fatal(err)
+			}
+			if err = sheet.AppendRow(nil, nil); err != nil {
+				t.Fatal(err)
+			}
+			if err = sheet.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if err = cf.AddConditionalFormat("A1", spreadsheet.ConditionalFormat{
+				Type: spreadsheet.ConditionDataBar, BarColor: "#638EC6",
+			}); err == nil {
+				t.Error("AddConditionalFormat succeeded after Close")
+			}
+			if err = w.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			const (
+				between = `-10,[.$B$1])`
+				formula = `AND([.B2]&lt;&gt;&#34;a,b&#34;; [.B2]&lt;TODAY(); [$&#39;Other sheet&#39;.$A$1:.B2]))`
+			)
+			wants := map[string][]string{
+				"styles.xml": {
+					`<office:styles>`,
+					`style:name="cf-`,
+					`<style:table-cell-properties fo:background-color="#FFC7CE" /><style:text-properties text:display="true" fo:color="#C00000" />`,
+				},
+			}
+			if version == ods.Version12 {
+				wants["content.xml"] = []string{
+					`<calcext:conditional-format calcext:target-range-address="&#39;Due&#39;&#39;s&#39;.A2:&#39;Due&#39;&#39;s&#39;.A10">`,
+					`calcext:value="between(` + between + `"`,
+					`calcext:value="formula-is(` + formula + `"`,
+					`calcext:base-cell-address="&#39;Due&#39;&#39;s&#39;.B2"`,
+					`<calcext:data-bar calcext:max-length="100" calcext:negative-color="#ff0000" calcext:positive-color="#638EC6"`,
+				}
+			} else {
+				wants["styles.xml"] = append(wants["styles.xml"],
+					`<style:text-properties text:display="true" fo:color="#000080" /><style:map style:condition="cell-content-is-between(`+between+`"`,
+					`<style:map style:condition="is-true-formula(`+formula+`"`,
+				)
+				wants["content.xml"] = []string{`<table:table-cell table:style-name="ce-`}
+			}
+			for name, wants := range wants {
+				rc, err := zr.Open(name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				b, err := io.ReadAll(rc)
+				rc.Close()
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, want := range wants {
+					if !bytes.Contains(b, []byte(want)) {
+						t.Errorf("%s: no %s", name, want)
+					}
+				}
+				if version == ods.Version13 && bytes.Contains(b, []byte("data-bar")) {
+					t.Errorf("%s: data bar in ODF 1.3", name)
+				}
+			}
+		})
+	}
+}
+
+func TestMergeCells(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := ods.NewWriter(&buf, ods.WithValidation(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bold := spreadsheet.Style{FontBold: true}
+	sheet, err := w.NewSheet("Quarters", []spreadsheet.Column{
+		{Name: "name"},
+		{Name: "Jan", Groups: []string{"2026", "Q1"}, Header: bold},
+		{Name: "Feb", Groups: []string{"2026", "Q1"}},
+		{Name: "Apr", Groups: []string{"2026", "Q2"}},
+		{Name: "total", Groups: []string{"sum"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := sheet.(spreadsheet.CellMerger)
+	if err = m.MergeCells("A3:B3"); err == nil {
+		t.Error("MergeCells of a header row succeeded")
+	}
+	if err = m.MergeCells("A4:B5"); err != nil {
+		t.Fatal(err)
+	}
+	if err = m.MergeCells("B5:C5"); err == nil {
+		t.Error("MergeCells of an overlapping range succeeded")
+	}
+	// the last row of the first range, and the second range are never appended
+	for _, ref := range []string{"D5:E6", "A8:B8"} {
+		if err = m.MergeCells(ref); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, row := range [][]any{{"merged", nil, 3}, {nil, nil, 4}} {
+		if err = sheet.AppendRow(row...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = m.MergeCells("C4:C4"); err == nil {
+		t.Error("MergeCells of an appended row succeeded")
+	}
+	if err = sheet.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rc, err := zr.Open("content.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		// the names span the header rows below their groups
+		`<table:table-row><table:table-cell office:value-type="string" table:number-rows-spanned="3"><text:p>name</text:p></table:table-cell>` +
+			`<table:table-cell office:value-type="string" table:style-name="ce-`,
+		`table:number-columns-spanned="3"><text:p>2026</text:p></table:table-cell><table:covered-table-cell/><table:covered-table-cell/>` +
+			`<table:table-cell office:value-type="string"><text:p>sum</text:p></table:table-cell></table:table-row>`,
+		`<table:table-row><table:covered-table-cell/><table:table-cell office:value-type="string" table:style-name="ce-`,
+		`table:number-columns-spanned="2"><text:p>Q1</text:p></table:table-cell><table:covered-table-cell/>` +
+			`<table:table-cell office:value-type="string"><text:p>Q2</text:p></table:table-cell>` +
+			`<table:table-cell office:value-type="string" table:number-rows-spanned="2"><text:p>total</text:p></table:table-cell></table:table-row>`,
+		`<table:table-row><table:covered-table-cell/><table:table-cell office:value-type="string" table:style-name="ce-`,
+		`<table:table-cell table:number-columns-spanned="2" table:number-rows-spanned="2"  office:value-type="string" >` +
+			`<text:p>merged</text:p>`,
+		`<table:covered-table-cell/><table:covered-table-cell/>`,
+		`<table:table-cell table:number-columns-spanned="2" table:number-rows-spanned="2"/><table:covered-table-cell/></table:table-row>`,
+		// the empty rows are appended up to the last merged row
+		`<table:table-row><table:table-cell/><table:table-cell/><table:table-cell/>` +
+			`<table:covered-table-cell/><table:covered-table-cell/></table:table-row>`,
+		`<table:table-row><table:table-cell table:number-columns-spanned="2"/><table:covered-table-cell/>`,
+	} {
+		if !bytes.Contains(b, []byte(want)) {
+			t.Errorf("no %s in %s", want, b)
+		}
+	}
+	if err = ods.Validate(bytes.NewReader(buf.Bytes()), int64(buf.Len())); err != nil {
+		t.Error(err)
+	}
+
+	r, err := ods.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rows [][]string
+	if err = r.ReadRows(context.Background(), "Quarters", func(row []string) error {
+		rows = append(rows, slices.Clone(row))
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if want := [][]string{{"name", "2026", "", "", "sum"}, {"", "Q1", "", "Q2", "total"}, {"", "Jan", "Feb", "Apr"}}; len(rows) < 3 ||
+		!reflect.DeepEqual(rows[:3], want) {
+		t.Errorf("got %q, wanted %q", rows, want)
+	}
+}
+
+func TestMergeCellsValidation(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := ods.NewWriter(&buf, ods.WithValidation(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sheet, err := w.NewSheet("Merged", []spreadsheet.Column{
+		{Name: "a", Validation: &spreadsheet.Validation{Type: spreadsheet.ValidateWhole, Min: 1}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = sheet.(spreadsheet.CellMerger).MergeCells("A3:A4"); err != nil {
+		t.Fatal(err)
+	}
+	if err = sheet.AppendRow(1); err != nil {
+		t.Fatal(err)
+	}
+	if err = sheet.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rc, err := zr.Open("content.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the of: prefix covers the whole condition
+	if want := `table:condition="of:cell-content-is-whole-number() and cell-content()&gt;=1"`; !bytes.Contains(b, []byte(want)) {
+		t.Errorf("no %s", want)
+	}
+	// the merged rows are appended before the validations' repeated row
+	if want := `<table:table-row><table:table-cell table:content-validation-name="val1" table:number-rows-spanned="2"/></table:table-row>
+<table:table-row><table:covered-table-cell table:content-validation-name="val1"/></table:table-row>
+<table:table-row table:number-rows-repeated="1048572"><table:table-cell table:content-validation-name="val1"/></table:table-row>`; !bytes.Contains(b, []byte(want)) {
+		t.Errorf("no %s in %s", want, b)
+	}
+	if err = ods.Validate(bytes.NewReader(buf.Bytes()), int64(buf.Len())); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestConditionalFormatConcurrent(t *testing.T) {
+	for _, version := range []string{ods.Version12, ods.Version13} {
+		t.Run(version, func(t *testing.T) {
+			w, err := ods.NewWriter(io.Discard, ods.WithVersion(version))
+			if err != nil {
+				t.Fatal(err)
+			}
+			sheet, err := w.NewSheet("Amounts", []spreadsheet.Column{{Name: "amount"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = sheet.(spreadsheet.ConditionalFormatter).AddConditionalFormat("A500:A1000", spreadsheet.ConditionalFormat{
+				Type: spreadsheet.ConditionCellValue, Operator: spreadsheet.OperatorLess, Value: "0",
+				Style: spreadsheet.Style{FontColor: "#C00000"},
+			}); err != nil {
+				t.Fatal(err)
+			}
+			started := make(chan struct{})
+			go func() {
+				defer sheet.Close()
+				for i := range 1000 {
+					if i == 10 {
+						// let Close wait for the sheet before the conditional rows
+						close(started)
+						time.Sleep(100 * time.Millisecond)
+					}
+					if err := sheet.AppendRow(i - 500); err != nil {
+						t.Error(err)
+						return
+					}
+				}
+			}()
+			<-started
+			closed := make(chan error, 1)
+			go func() { closed <- w.Close() }()
+			select {
+			case err = <-closed:
+				if err != nil {
+					t.Fatal(err)
+				}
+			case <-time.After(10 * time.Second):
+				t.Fatal("Close hangs")
+			}
+		})
+	}
+}
+
+func TestReadRepeated(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("content.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+ xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0">
+<office:body><office:spreadsheet><table:table table:name="Sheet1">
+<table:table-row><table:table-cell table:number-columns-repeated="16000"/><table:table-cell office:value-type="float" office:value="1"/>
+<table:table-cell table:number-columns-repeated="1000000000"/></table:table-row>
+<table:table-row table:number-rows-repeated="2"><table:table-cell table:number-columns-repeated="1000000000"/></table:table-row>
+<table:table-row><table:table-cell office:value-type="string"><text:p>a</text:p></table:table-cell>
+<table:table-cell table:number-columns-repeated="2"/><table:table-cell office:value-type="string" table:number-columns-repeated="2"><text:p>b</text:p></table:table-cell></table:table-row>
+<table:table-row><table:table-cell table:number-columns-repeated="20000"/><table:table-cell office:value-type="float" office:value="2"/></table:table-row>
+</table:table></office:spreadsheet></office:body></office:document-content>`)
+	if err = zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	r, err := ods.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rows [][]string
+	err = r.ReadRows(context.Background(), "Sheet1", func(row []string) error {
+		rows = append(rows, slices.Clone(row))
+		return nil
+	})
+	if err == nil {
+		t.Error("no error for too many columns")
+	}
+	if len(rows) != 4 {
+		t.Fatalf("got %d rows, wanted 4", len(rows))
+	}
+	if got := rows[0]; len(got) != 16001 || got[16000] != "1" {
+		t.Errorf("got %d cells (last %q), wanted the value in column 16001", len(got), got[len(got)-1])
+	}
+	if rows[1] != nil || rows[2] != nil {
+		t.Errorf("got %q, wanted empty rows", rows[1:3])
+	}
+	if want := []string{"a", "", "", "b", "b"}; !slices.Equal(rows[3], want) {
+		t.Errorf("got %q, wanted %q", rows[3], want)
+	}
+}
+
+func TestInvalidColor(t *testing.T) {
+	w, err := ods.NewWriter(io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	for _, col := range []spreadsheet.Column{
+		{Name: "header", Header: spreadsheet.Style{FontColor: "red"}},
+		{Name: "column", Column: spreadsheet.Style{BackgroundColor: "#12345"}},
+	} {
+		if _, err = w.NewSheet("Colors", []spreadsheet.Column{col}); !errors.Is(err, spreadsheet.ErrInvalidStyle) {
+			t.Errorf("%s: got %v, wanted %v", col.Name, err, spreadsheet.ErrInvalidStyle)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ods
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/zip"
+
+	"github.com/UNO-SOFT/spreadsheet"
+)
+
+var _ = (spreadsheet.Reader)((*ODSReader)(nil))
+
+const (
+	nsOffice = "urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+	nsTable  = "urn:oasis:names:tc:opendocument:xmlns:table:1.0"
+	nsText   = "urn:oasis:names:tc:opendocument:xmlns:text:1.0"
+)
+
+// ODSReader reads an ods file.
+type ODSReader struct {
+	content *zip.File
+	sheets  []string
+}
+
+// NewReader returns a spreadsheet.Reader for the ods file.
+func NewReader(r io.ReaderAt, size int64) (*ODSReader, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	or := ODSReader{}
+	for _, f := range zr.File {
+		if f.Name == "content.xml" {
+			or.content = f
+			break
+		}
+	}
+	if or.content == nil {
+		return nil, errors.New("no content.xml in the zip")
+	}
+	err = or.decode(func(dec *xml.Decoder, se xml.StartElement) error {
+		or.sheets = append(or.sheets, attr(se, nsTable, "name"))
+		return dec.Skip()
+	})
+	return &or, err
+}
+
+// Sheets returns the names of the sheets.
+func (or *ODSReader) Sheets() []string { return or.sheets }
+
+// decode calls fn with each table:table element of content.xml.
+func (or *ODSReader) decode(fn func(*xml.Decoder, xml.StartElement) error) error {
+	rc, err := or.content.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	dec := xml.NewDecoder(rc)
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if se, ok := tok.(xml.StartElement); ok && se.Name.Space == nsTable && se.Name.Local == "table" {
+			if err = fn(dec, se); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// errStop stops the decoding after the sheet has been read.
+var errStop = errors.New("stop")
+
+// ReadRows calls fn with each row of the sheet.
+//
+// The values are the office:value (for floats, percentages and currencies),
+// office:date-value, office:time-value and office:boolean-value attributes,
+// or the text of the cell. The trailing empty cells and rows are omitted.
+func (or *ODSReader) ReadRows(ctx context.Context, sheetName string, fn func(row []string) error) error {
+	var found bool
+	err := or.decode(func(dec *xml.Decoder, se xml.StartElement) error {
+		if attr(se, nsTable, "name") != sheetName {
+			return dec.Skip()
+		}
+		found = true
+		if err := readTable(ctx, dec, fn); err != nil {
+			return err
+		}
+		return errStop
+	})
+	if err == errStop {
+		err = nil
+	}
+	if err == nil && !found {
+		err = fmt.Errorf("sheet %q not found", sheetName)
+	}
+	return err
+}
+
+func readTable(ctx context.Context, dec *xml.Decoder, fn func(row []string) error) error {
+	var row []string
+	var emptyRows int
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.EndElement:
+			if tok.Name.Space == nsTable && tok.Name.Local == "table" {
+				return nil
+			}
+		case xml.StartElement:
+			if tok.Name.Space != nsTable || tok.Name.Local != "table-row" {
+				if tok.Name.Space == nsTable && (tok.Name.Local == "table-column" || tok.Name.Local == "shapes") {
+					if err = dec.Skip(); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+			if err = ctx.Err(); err != nil {
+				return err
+			}
+			repeat := repeated(tok, "number-rows-repeated")
+			if row, err = readRow(dec, row[:0]); err != nil {
+				return err
+			}
+			if len(row) == 0 {
+				emptyRows += repeat
+				continue
+			}
+			for ; emptyRows > 0; emptyRows-- {
+				if err = fn(nil); err != nil {
+					return err
+				}
+			}
+			for range repeat {
+				if err = fn(row); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// maxColumnCount is the maximum number of columns of a sheet.
+const maxColumnCount = 1 << 14
+
+// readRow reads the cells of the table:table-row, omitting the trailing empty cells.
+func readRow(dec *xml.Decoder, row []string) ([]string, error) {
+	var empty int // the number of empty cells since the last non-empty
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return row, err
+		}
+		switch tok := tok.(type) {
+		case xml.EndElement:
+			if tok.Name.Space == nsTable && tok.Name.Local == "table-row" {
+				return row, nil
+			}
+		case xml.StartElement:
+			if tok.Name.Space != nsTable || (tok.Name.Local != "table-cell" && tok.Name.Local != "covered-table-cell") {
+				continue
+			}
+			repeat := min(repeated(tok, "number-columns-repeated"), maxColumnCount+1)
+			value, err := readCell(dec, tok)
+			if err != nil {
+				return row, err
+			}
+			if value == "" {
+				// do not materialize the (usually huge) trailing empty cells
+				empty += repeat
+				continue
+			}
+			if len(row)+empty+repeat > maxColumnCount {
+				return row, fmt.Errorf("more than %d columns", maxColumnCount)
+			}
+			for ; empty > 0; empty-- {
+				row = append(row, "")
+			}
+			for range repeat {
+				row = append(row, value)
+			}
+		}
+	}
+}
+
+// readCell returns the value of the cell.
+func readCell(dec *xml.Decoder, se xml.StartElement) (string, error) {
+	var value string
+	switch attr(se, nsOffice, "value-type") {
+	case "float", "percentage", "currency":
+		value = attr(se, nsOffice, "value")
+	case "date":
+		value = attr(se, nsOffice, "date-value")
+	case "time":
+		value = attr(se, nsOffice, "time-value")
+	case "boolean":
+		value = attr(se, nsOffice, "boolean-value")
+	case "string":
+		value = attr(se, nsOffice, "string-value")
+	}
+	if value != "" {
+		return value, dec.Skip()
+	}
+	var buf strings.Builder
+	var paragraphs, depth int
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return buf.String(), err
+		}
+		switch tok := tok.(type) {
+		case xml.CharData:
+			if depth > 0 {
+				buf.Write(tok)
+			}
+		case xml.StartElement:
+			if tok.Name.Space != nsText {
+				if err = dec.Skip(); err != nil {
+					return buf.String(), err
+				}
+				continue
+			}
+			switch tok.Name.Local {
+			case "p", "h":
+				if paragraphs != 0 {
+					buf.WriteByte('\n')
+				}
+				paragraphs++
+			case "s":
+				n := 1
+				if c := attr(tok, nsText, "c"); c != "" {
+					n, _ = strconv.Atoi(c)
+				}
+				buf.WriteString(strings.Repeat(" ", max(1, n)))
+			case "tab":
+				buf.WriteByte('\t')
+			case "line-break":
+				buf.WriteByte('\n')
+			}
+			depth++
+		case xml.EndElement:
+			if tok.Name == se.Name {
+				return buf.String(), nil
+			}
+			depth--
+		}
+	}
+}
+
+func attr(se xml.StartElement, space, local string) string {
+	for _, a := range se.Attr {
+		if a.Name.Space == space && a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+func repeated(se xml.StartElement, name string) int {
+	if s := attr(se, nsTable, name); s != "" {
+		if n, err := strconv.Atoi(s); err == nil && n > 0 {
+			return n
+		}
+	}
+	return 1
+}
@@ -0,0 +1,259 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ods
+
+import (
+	"encoding/binary"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/klauspost/compress/zip"
+)
+
+const (
+	nsManifest = "urn:oasis:names:tc:opendocument:xmlns:manifest:1.0"
+	nsStyle    = "urn:oasis:names:tc:opendocument:xmlns:style:1.0"
+
+	manifestPath = "META-INF/manifest.xml"
+	mimetypePath = "mimetype"
+)
+
+// ErrInvalid is wrapped by the errors of Validate.
+var ErrInvalid = errors.New("invalid ODF package")
+
+// ValidationError is a violation of the ODF structural rules in a part of the package.
+type ValidationError struct {
+	// Part is the name of the zip entry, empty for the whole package.
+	Part string
+	Err  error
+}
+
+func (e *ValidationError) Error() string {
+	if e.Part == "" {
+		return e.Err.Error()
+	}
+	return e.Part + ": " + e.Err.Error()
+}
+func (e *ValidationError) Unwrap() []error { return []error{ErrInvalid, e.Err} }
+
+// Validate checks the ODF 1.2/1.3 structural rules the ods package relies on:
+//   - the mimetype entry is the first, stored uncompressed, without extra field,
+//   - the manifest entries match the zip contents,
+//   - every XML part is well-formed, and declares the same version as the manifest
+//     (the encrypted parts are not checked),
+//   - the style names referenced in content.xml and styles.xml are defined.
+//
+// The returned error joins a *ValidationError for each violation.
+func Validate(r io.ReaderAt, size int64) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return &ValidationError{Err: err}
+	}
+	var v validator
+	v.checkMimetype(r, zr)
+	mediaTypes, encrypted := v.checkManifest(zr)
+
+	styles := newStyleNames()
+	versions := make(map[string]string)
+	for _, f := range zr.File {
+		if strings.HasSuffix(f.Name, "/") || encrypted[f.Name] ||
+			!strings.HasSuffix(f.Name, ".xml") && mediaTypes[f.Name] != "text/xml" {
+			continue
+		}
+		version, err := checkXML(f, styles)
+		if err != nil {
+			v.add(f.Name, err)
+		} else if version != "" {
+			versions[f.Name] = version
+		}
+	}
+	if want := versions[manifestPath]; want != "" {
+		for part, version := range versions {
+			if version != want {
+				v.add(part, fmt.Errorf("version %q differs from the manifest's %q", version, want))
+			}
+		}
+	}
+	reported := make(map[styleRef]bool)
+	for _, ref := range styles.refs {
+		if !styles.defined[ref.name] && !reported[ref] {
+			reported[ref] = true
+			v.add(ref.part, fmt.Errorf("undefined style %q referenced in %s", ref.name, ref.attr))
+		}
+	}
+	return errors.Join(v.errs...)
+}
+
+type validator struct {
+	errs []error
+}
+
+func (v *validator) add(part string, err error) {
+	v.errs = append(v.errs, &ValidationError{Part: part, Err: err})
+}
+
+// checkMimetype checks the mimetype entry, and its local header:
+// the media type must be readable at offset 38.
+func (v *validator) checkMimetype(r io.ReaderAt, zr *zip.Reader) {
+	if len(zr.File) == 0 || zr.File[0].Name != mimetypePath {
+		v.add(mimetypePath, errors.New("not the first entry"))
+		return
+	}
+	f := zr.File[0]
+	if f.Method != zip.Store {
+		v.add(mimetypePath, fmt.Errorf("compressed with method %d", f.Method))
+	}
+	var hdr [30]byte
+	if _, err := r.ReadAt(hdr[:], 0); err != nil {
+		v.add(mimetypePath, err)
+		return
+	}
+	if string(hdr[:4]) != "PK\x03\x04" {
+		v.add(mimetypePath, errors.New("no local file header at the start"))
+		return
+	}
+	if n := binary.LittleEndian.Uint16(hdr[28:]); n != 0 {
+		v.add(mimetypePath, fmt.Errorf("has %d bytes of extra field", n))
+	}
+	rc, err := f.Open()
+	if err != nil {
+		v.add(mimetypePath, err)
+		return
+	}
+	b, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		v.add(mimetypePath, err)
+	} else if !strings.HasPrefix(string(b), "application/vnd.oasis.opendocument.") {
+		v.add(mimetypePath, fmt.Errorf("unknown media type %q", b))
+	}
+}
+
+// checkManifest checks that the manifest lists exactly the files of the package,
+// and returns the media types of the entries, and the encrypted entries.
+func (v *validator) checkManifest(zr *zip.Reader) (map[string]string, map[string]bool) {
+	i := slices.IndexFunc(zr.File, func(f *zip.File) bool { return f.Name == manifestPath })
+	if i < 0 {
+		v.add(manifestPath, errors.New("missing"))
+		return nil, nil
+	}
+	rc, err := zr.File[i].Open()
+	if err != nil {
+		v.add(manifestPath, err)
+		return nil, nil
+	}
+	var manifest struct {
+		Entries []struct {
+			FullPath   string `xml:"urn:oasis:names:tc:opendocument:xmlns:manifest:1.0 full-path,attr"`
+			MediaType  string `xml:"urn:oasis:names:tc:opendocument:xmlns:manifest:1.0 media-type,attr"`
+			Encryption *struct {
+				Algorithm struct {
+					Name string `xml:"urn:oasis:names:tc:opendocument:xmlns:manifest:1.0 algorithm-name,attr"`
+				} `xml:"urn:oasis:names:tc:opendocument:xmlns:manifest:1.0 algorithm"`
+			} `xml:"urn:oasis:names:tc:opendocument:xmlns:manifest:1.0 encryption-data"`
+		} `xml:"urn:oasis:names:tc:opendocument:xmlns:manifest:1.0 file-entry"`
+	}
+	err = xml.NewDecoder(rc).Decode(&manifest)
+	rc.Close()
+	if err != nil {
+		v.add(manifestPath, err)
+		return nil, nil
+	}
+	mediaTypes := make(map[string]string, len(manifest.Entries))
+	encrypted := make(map[string]bool)
+	for _, e := range manifest.Entries {
+		mediaTypes[e.FullPath] = e.MediaType
+		if e.Encryption != nil {
+			encrypted[e.FullPath] = true
+			if e.Encryption.Algorithm.Name == "" {
+				v.add(manifestPath, fmt.Errorf("no encryption algorithm for %q", e.FullPath))
+			}
+		}
+		if e.FullPath == "/" || strings.HasSuffix(e.FullPath, "/") {
+			continue
+		}
+		if !slices.ContainsFunc(zr.File, func(f *zip.File) bool { return f.Name == e.FullPath }) {
+			v.add(manifestPath, fmt.Errorf("lists the missing %q", e.FullPath))
+		}
+	}
+	if _, ok := mediaTypes["/"]; !ok {
+		v.add(manifestPath, errors.New("no entry for the package root"))
+	}
+	for _, f := range zr.File {
+		if f.Name == mimetypePath || strings.HasPrefix(f.Name, "META-INF/") || strings.HasSuffix(f.Name, "/") {
+			continue
+		}
+		if _, ok := mediaTypes[f.Name]; !ok {
+			v.add(f.Name, errors.New("not listed in the manifest"))
+		}
+	}
+	return mediaTypes, encrypted
+}
+
+// styleNames collects the defined and the referenced style names.
+type styleNames struct {
+	defined map[string]bool
+	refs    []styleRef
+}
+type styleRef struct {
+	part, attr, name string
+}
+
+func newStyleNames() *styleNames { return &styleNames{defined: make(map[string]bool)} }
+
+// checkXML checks the well-formedness of the XML part,
+// collecting the style names of content.xml and styles.xml.
+//
+// Returns the version declared by the root element.
+func checkXML(f *zip.File, styles *styleNames) (string, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	collect := f.Name == "content.xml" || f.Name == "styles.xml"
+	dec := xml.NewDecoder(rc)
+	var hasRoot bool
+	var version string
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			if err == io.EOF {
+				if !hasRoot {
+					return "", errors.New("no root element")
+				}
+				return version, nil
+			}
+			return "", err
+		}
+		se, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if !hasRoot {
+			hasRoot = true
+			if version = attr(se, nsOffice, "version"); version == "" {
+				version = attr(se, nsManifest, "version")
+			}
+		}
+		if !collect {
+			continue
+		}
+		for _, a := range se.Attr {
+			switch {
+			case a.Name.Space == nsStyle && a.Name.Local == "name":
+				styles.defined[a.Value] = true
+			case strings.HasSuffix(a.Name.Local, "style-name"):
+				styles.refs = append(styles.refs, styleRef{
+					part: f.Name, attr: se.Name.Local + "/@" + a.Name.Local, name: a.Value,
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ods
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+
+	"github.com/UNO-SOFT/spreadsheet"
+)
+
+// addValidation adds the table:content-validation for the column's cells,
+// and returns its name.
+func (ow *ODSWriter) addValidation(sheetName string, col, firstRow int, v spreadsheet.Validation) (string, error) {
+	cond, err := validationCondition(v)
+	if err != nil {
+		return "", err
+	}
+	name := "val" + strconv.Itoa(len(ow.validations)+1)
+	var buf strings.Builder
+	buf.WriteString(`<table:content-validation table:name="` + name + `" table:condition="`)
+	xml.EscapeText(&buf, []byte(cond))
+	buf.WriteString(`" table:allow-empty-cell="true"`)
+	if v.Type == spreadsheet.ValidateList {
+		buf.WriteString(` table:display-list="unsorted"`)
+	}
+	buf.WriteString(` table:base-cell-address="`)
+	xml.EscapeText(&buf, []byte(sheetRef(sheetName)+"."+spreadsheet.ColumnName(col)+strconv.Itoa(firstRow)))
+	// without an error message, the invalid values are accepted
+	buf.WriteString(`"><table:error-message table:display="true" table:message-type="stop"`)
+	if v.ErrorTitle != "" {
+		buf.WriteString(` table:title="`)
+		xml.EscapeText(&buf, []byte(v.ErrorTitle))
+		buf.WriteString(`"`)
+	}
+	buf.WriteString(`>`)
+	if v.ErrorMessage != "" {
+		for _, line := range strings.Split(v.ErrorMessage, "\n") {
+			buf.WriteString(`<text:p>`)
+			xml.EscapeText(&buf, []byte(line))
+			buf.WriteString(`</text:p>`)
+		}
+	}
+	buf.WriteString(`</table:error-message></table:content-validation>`)
+	ow.validations = append(ow.validations, buf.String())
+	return name, nil
+}
+
+// validationCondition returns the table:condition of the validation.
+func validationCondition(v spreadsheet.Validation) (string, error) {
+	lo, hi, err := v.Bounds()
+	if err != nil {
+		return "", err
+	}
+	if v.Type == spreadsheet.ValidateList {
+		if len(v.List) == 0 {
+			return "of:cell-content-is-in-list(" + rangeAddress(v.ListRange) + ")", nil
+		}
+		quoted := make([]string, len(v.List))
+		for i, s := range v.List {
+			quoted[i] = `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+		}
+		return "of:cell-content-is-in-list(" + strings.Join(quoted, ";") + ")", nil
+	}
+	if v.Type == spreadsheet.ValidateTextLength {
+		switch {
+		case lo != "" && hi != "":
+			return "of:cell-content-text-length-is-between(" + lo + ";" + hi + ")", nil
+		case lo != "":
+			return "of:cell-content-text-length()>=" + lo, nil
+		default:
+			return "of:cell-content-text-length()<=" + hi, nil
+		}
+	}
+	var cond string
+	switch v.Type {
+	case spreadsheet.ValidateWhole:
+		cond = "of:cell-content-is-whole-number()"
+	case spreadsheet.ValidateDecimal:
+		cond = "of:cell-content-is-decimal-number()"
+	case spreadsheet.ValidateDate:
+		cond = "of:cell-content-is-date()"
+	}
+	switch {
+	case lo != "" && hi != "":
+		return cond + " and cell-content-is-between(" + lo + ";" + hi + ")", nil
+	case lo != "":
+		return cond + " and cell-content()>=" + lo, nil
+	default:
+		return cond + " and cell-content()<=" + hi, nil
+	}
+}
+
+// rangeAddress converts the "Sheet!A1:A10" range to the "[$'Sheet'.A1:.A10]" form.
+func rangeAddress(ref string) string {
+	var sheet string
+	if i := strings.LastIndexByte(ref, '!'); i >= 0 {
+		sheet, ref = ref[:i], ref[i+1:]
+		if len(sheet) >= 2 && sheet[0] == '\'' && sheet[len(sheet)-1] == '\'' {
+			sheet = strings.ReplaceAll(sheet[1:len(sheet)-1], "''", "'")
+		}
+		sheet = "$" + sheetRef(sheet)
+	}
+	return "[" + sheet + "." + strings.Replace(ref, ":", ":.", 1) + "]"
+}
+
+// sheetRef returns the quoted sheet name for the cell addresses.
+func sheetRef(name string) string { return "'" + strings.ReplaceAll(name, "'", "''") + "'" }
@@ -0,0 +1,551 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package parquet
+
+import (
+	"cmp"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zip"
+	"github.com/parquet-go/parquet-go"
+
+	"github.com/UNO-SOFT/spreadsheet"
+	"github.com/UNO-SOFT/spreadsheet/internal/files"
+	"github.com/UNO-SOFT/spreadsheet/internal/format"
+	"github.com/UNO-SOFT/spreadsheet/internal/spool"
+)
+
+var _ = (spreadsheet.Writer)((*ParquetWriter)(nil))
+
+// DefaultRowGroupSize is the default maximum number of rows in a row group.
+const DefaultRowGroupSize = 128 << 10
+
+// batchSize is the number of rows buffered before handing them to the parquet writer.
+const batchSize = 1024
+
+// Option is an option for the Parquet writers.
+type Option func(*options)
+
+type options struct {
+	rowGroupSize int64
+}
+
+// WithRowGroupSize sets the maximum number of rows in a row group.
+func WithRowGroupSize(n int64) Option { return func(o *options) { o.rowGroupSize = n } }
+
+func newOptions(opts []Option) options {
+	o := options{rowGroupSize: DefaultRowGroupSize}
+	for _, f := range opts {
+		f(&o)
+	}
+	return o
+}
+
+// ParquetWriter writes each sheet as a separate Parquet file.
+type ParquetWriter struct {
+	create func(name string) (io.WriteCloser, error)
+	spool  *spool.Spool
+	zw     *zip.Writer
+	opts   options
+	mu     sync.Mutex
+}
+
+// NewFilesWriter returns a spreadsheet.Writer that writes each sheet
+// into the io.WriteCloser returned by create.
+//
+// This writer allows concurrent writes to separate sheets.
+func NewFilesWriter(create func(name string) (io.WriteCloser, error), opts ...Option) *ParquetWriter {
+	return &ParquetWriter{create: create, opts: newOptions(opts)}
+}
+
+// NewDirWriter returns a spreadsheet.Writer that writes each sheet
+// into the "name.parquet" file in the dir directory.
+//
+// This writer allows concurrent writes to separate sheets.
+func NewDirWriter(dir string, opts ...Option) (*ParquetWriter, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return NewFilesWriter(func(name string) (io.WriteCloser, error) {
+		return os.Create(filepath.Join(dir, FileName(name)))
+	}, opts...), nil
+}
+
+// NewZipWriter returns a spreadsheet.Writer that writes a zip into w,
+// with one "name.parquet" entry per sheet.
+//
+// This writer allows concurrent writes to separate sheets.
+func NewZipWriter(w io.Writer, opts ...Option) *ParquetWriter {
+	return &ParquetWriter{spool: spool.New(w), zw: zip.NewWriter(w), opts: newOptions(opts)}
+}
+
+// FileName returns the file name for the sheet name: the path separators
+// and other problematic characters are replaced, and ".parquet" is appended.
+func FileName(name string) string { return files.Name(name, ".parquet") }
+
+// Close the writer: copies the finished sheets and closes the zip.
+//
+// Does not close the underlying io.Writer.
+func (pw *ParquetWriter) Close() error {
+	if pw == nil {
+		return nil
+	}
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+	sp, zw := pw.spool, pw.zw
+	pw.spool, pw.zw, pw.create = nil, nil, nil
+	if sp != nil {
+		if err := sp.Close(); err != nil {
+			return err
+		}
+	}
+	if zw != nil {
+		return zw.Close()
+	}
+	return nil
+}
+
+// NewSheet returns a new sheet.
+//
+// The schema is inferred from the columns and the types of the first row's values:
+// integers are stored as INT64, floats as DOUBLE, time.Time as TIMESTAMP(MICROS),
+// spreadsheet.Number as DECIMAL(38, scale) - the scale comes from the column's
+// number format, or the first value - and everything else as STRING.
+//
+// The sql.Null* types, time.Time and spreadsheet.Number result in nullable (OPTIONAL) columns.
+func (pw *ParquetWriter) NewSheet(name string, cols []spreadsheet.Column) (spreadsheet.Sheet, error) {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+	var w io.WriteCloser
+	var err error
+	switch {
+	case pw.create != nil:
+		w, err = pw.create(name)
+	case pw.zw != nil:
+		zw := pw.zw
+		w, err = pw.spool.NewPart(func(io.Writer) (io.Writer, error) {
+			return zw.CreateHeader(&zip.FileHeader{
+				Name: FileName(name), Method: zip.Store, Modified: time.Now(),
+			})
+		})
+	default:
+		return nil, os.ErrClosed
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ParquetSheet{Name: name, dst: w, cols: cols, opts: &pw.opts}, nil
+}
+
+// ParquetSheet is a sheet written as a Parquet file.
+type ParquetSheet struct {
+	dst    io.WriteCloser
+	w      *parquet.Writer
+	opts   *options
+	Name   string
+	cols   []spreadsheet.Column
+	fields []field
+	rows   []parquet.Row
+	mu     sync.Mutex
+}
+
+// AppendRow appends the row - the first row determines the schema.
+func (ps *ParquetSheet) AppendRow(values ...any) error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if ps.dst == nil {
+		return os.ErrClosed
+	}
+	if ps.fields == nil {
+		ps.init(values)
+	}
+	if ps.w == nil { // no columns
+		if len(values) != 0 {
+			return fmt.Errorf("%s: got %d values, the schema has no columns", ps.Name, len(values))
+		}
+		return nil
+	}
+	row := make(parquet.Row, len(ps.fields))
+	for i := range ps.fields {
+		f := &ps.fields[i]
+		var v any
+		if i < len(values) {
+			v = values[i]
+		}
+		pv, err := f.value(v)
+		if err != nil {
+			return fmt.Errorf("%s[%d] %q: %w", ps.Name, i, f.Name, err)
+		}
+		var def int
+		if f.Optional && !pv.IsNull() {
+			def = 1
+		}
+		row[f.Index] = pv.Level(0, def, f.Index)
+	}
+	if len(values) > len(ps.fields) {
+		return fmt.Errorf("%s: got %d values, the schema has only %d columns", ps.Name, len(values), len(ps.fields))
+	}
+	ps.rows = append(ps.rows, row)
+	if len(ps.rows) >= batchSize {
+		return ps.flush()
+	}
+	return nil
+}
+
+func (ps *ParquetSheet) flush() error {
+	if len(ps.rows) == 0 {
+		return nil
+	}
+	_, err := ps.w.WriteRows(ps.rows)
+	ps.rows = ps.rows[:0]
+	return err
+}
+
+// Close the sheet: writes the remaining rows and the footer.
+func (ps *ParquetSheet) Close() error {
+	if ps == nil {
+		return nil
+	}
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	dst := ps.dst
+	if dst == nil {
+		return nil
+	}
+	if ps.fields == nil {
+		ps.init(nil)
+	}
+	ps.dst = nil
+	var err error
+	if ps.w != nil {
+		err = ps.flush()
+		if closeErr := ps.w.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	if closeErr := dst.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// init creates the schema and the parquet.Writer from the columns and the first row.
+//
+// Parquet files need at least one column, so without columns the file remains empty.
+func (ps *ParquetSheet) init(values []any) {
+	n := max(len(ps.cols), len(values))
+	ps.fields = make([]field, n)
+	if n == 0 {
+		return
+	}
+	group := columnGroup{Group: make(parquet.Group, n), names: make([]string, n)}
+	seen := make(map[string]struct{}, n)
+	for i := range ps.fields {
+		f := &ps.fields[i]
+		var v any
+		if i < len(values) {
+			v = values[i]
+		}
+		if i < len(ps.cols) {
+			f.Name = ps.cols[i].Name
+			f.Scale = scaleOf(ps.cols[i].Column.Format)
+		}
+		if f.Name == "" {
+			f.Name = spreadsheet.ColumnName(i)
+		}
+		for j := 2; ; j++ {
+			if _, ok := seen[f.Name]; !ok {
+				break
+			}
+			f.Name = strings.TrimSuffix(f.Name, "_"+strconv.Itoa(j-1)) + "_" + strconv.Itoa(j)
+		}
+		seen[f.Name] = struct{}{}
+		group.Group[f.Name] = f.infer(v)
+		group.names[i] = f.Name
+	}
+	schema := parquet.NewSchema(ps.Name, group)
+	index := make(map[string]int, n)
+	for i, path := range schema.Columns() {
+		index[path[0]] = i
+	}
+	for i := range ps.fields {
+		ps.fields[i].Index = index[ps.fields[i].Name]
+	}
+	ps.w = parquet.NewWriter(ps.dst, schema,
+		parquet.MaxRowsPerRowGroup(ps.opts.rowGroupSize),
+		parquet.Compression(&parquet.Snappy),
+	)
+}
+
+// columnGroup is a parquet.Group keeping the order of the columns,
+// as parquet.Group orders its fields by name.
+type columnGroup struct {
+	parquet.Group
+	names []string
+}
+
+// Fields returns the fields in the order of the columns.
+func (g columnGroup) Fields() []parquet.Field {
+	fields := g.Group.Fields()
+	order := make(map[string]int, len(g.names))
+	for i, name := range g.names {
+		order[name] = i
+	}
+	slices.SortFunc(fields, func(a, b parquet.Field) int {
+		return cmp.Compare(order[a.Name()], order[b.Name()])
+	})
+	return fields
+}
+
+func (g columnGroup) String() string {
+	var buf strings.Builder
+	_ = parquet.PrintSchema(&buf, "", g)
+	return buf.String()
+}
+
+type kind uint8
+
+const (
+	kindString = kind(iota)
+	kindInt
+	kindUint
+	kindFloat
+	kindBool
+	kindTime
+	kindDecimal
+)
+
+// decimalPrecision is the precision of the DECIMAL columns, stored as 16 bytes.
+const decimalPrecision = 38
+
+type field struct {
+	Name     string
+	Index    int
+	Scale    int
+	Kind     kind
+	Optional bool
+}
+
+// scaleOf returns the number of decimal places of the number format, or -1.
+func scaleOf(numFmt string) int {
+	if numFmt == "" || format.IsDateFormat(numFmt) {
+		return -1
+	}
+	if i := strings.IndexByte(numFmt, ';'); i >= 0 {
+		numFmt = numFmt[:i]
+	}
+	i := strings.IndexByte(numFmt, '.')
+	if i < 0 {
+		if strings.ContainsAny(numFmt, "0#") {
+			return 0
+		}
+		return -1
+	}
+	var n int
+	for _, c := range numFmt[i+1:] {
+		if c != '0' && c != '#' {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+// infer the type of the field from the value.
+func (f *field) infer(v any) parquet.Node {
+	switch x := v.(type) {
+	case nil:
+		f.Kind, f.Optional = kindString, true
+	case sql.NullInt64, sql.NullInt32, sql.NullInt16, sql.NullByte:
+		f.Kind, f.Optional = kindInt, true
+	case sql.NullFloat64:
+		f.Kind, f.Optional = kindFloat, true
+	case sql.NullBool:
+		f.Kind, f.Optional = kindBool, true
+	case sql.NullTime, time.Time:
+		f.Kind, f.Optional = kindTime, true
+	case sql.NullString:
+		f.Kind, f.Optional = kindString, true
+	case spreadsheet.Number:
+		f.Kind, f.Optional = kindDecimal, true
+		if f.Scale < 0 {
+			f.Scale = 0
+			if i := strings.IndexByte(string(x), '.'); i >= 0 {
+				f.Scale = len(x) - i - 1
+			}
+		}
+	case int, int8, int16, int32, int64, uint8, uint16, uint32:
+		f.Kind = kindInt
+	case uint, uint64:
+		f.Kind = kindUint
+	case float32, float64:
+		f.Kind = kindFloat
+	case bool:
+		f.Kind = kindBool
+	case driver.Valuer:
+		vv, _ := x.Value()
+		f.infer(vv)
+		f.Optional = true
+		return f.node()
+	default:
+		f.Kind = kindString
+	}
+	return f.node()
+}
+
+func (f *field) node() parquet.Node {
+	var node parquet.Node
+	switch f.Kind {
+	case kindInt:
+		node = parquet.Int(64)
+	case kindUint:
+		node = parquet.Uint(64)
+	case kindFloat:
+		node = parquet.Leaf(parquet.DoubleType)
+	case kindBool:
+		node = parquet.Leaf(parquet.BooleanType)
+	case kindTime:
+		node = parquet.Timestamp(parquet.Microsecond)
+	case kindDecimal:
+		node = parquet.Decimal(f.Scale, decimalPrecision, parquet.FixedLenByteArrayType(16))
+	default:
+		node = parquet.String()
+	}
+	if f.Optional {
+		node = parquet.Optional(node)
+	}
+	return node
+}
+
+var errNotNullable = errors.New("null in a not nullable column")
+
+// value converts v to the parquet.Value of the field's type.
+func (f *field) value(v any) (parquet.Value, error) {
+	v = format.Value(v)
+	if v == nil {
+		if !f.Optional {
+			return parquet.Value{}, errNotNullable
+		}
+		return parquet.Value{}, nil
+	}
+	switch f.Kind {
+	case kindInt:
+		switch x := v.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32:
+			i, _ := strconv.ParseInt(fmt.Sprintf("%d", x), 10, 64)
+			return parquet.Int64Value(i), nil
+		case uint64:
+			if x > math.MaxInt64 {
+				return parquet.Value{}, fmt.Errorf("%d overflows int64", x)
+			}
+			return parquet.Int64Value(int64(x)), nil
+		case spreadsheet.Number:
+			i, err := strconv.ParseInt(string(x), 10, 64)
+			return parquet.Int64Value(i), err
+		case float64:
+			if x == math.Trunc(x) && math.Abs(x) < 1<<63 {
+				return parquet.Int64Value(int64(x)), nil
+			}
+		}
+	case kindUint:
+		// UINT_64 is stored in INT64, as the same bits
+		var s string
+		switch x := v.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			s = fmt.Sprintf("%d", x)
+		case spreadsheet.Number:
+			s = string(x)
+		}
+		if s != "" {
+			u, err := strconv.ParseUint(s, 10, 64)
+			return parquet.Int64Value(int64(u)), err
+		}
+	case kindFloat:
+		switch x := v.(type) {
+		case float64:
+			return parquet.DoubleValue(x), nil
+		case float32:
+			return parquet.DoubleValue(float64(x)), nil
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			f, _ := strconv.ParseFloat(fmt.Sprintf("%d", x), 64)
+			return parquet.DoubleValue(f), nil
+		case spreadsheet.Number:
+			f, err := strconv.ParseFloat(string(x), 64)
+			return parquet.DoubleValue(f), err
+		}
+	case kindBool:
+		if x, ok := v.(bool); ok {
+			return parquet.BooleanValue(x), nil
+		}
+	case kindTime:
+		if x, ok := v.(time.Time); ok {
+			if x.IsZero() {
+				return f.value(nil)
+			}
+			return parquet.Int64Value(x.UnixMicro()), nil
+		}
+	case kindDecimal:
+		var s string
+		switch x := v.(type) {
+		case spreadsheet.Number:
+			if x == "" {
+				return f.value(nil)
+			}
+			s = string(x)
+		case string:
+			s = x
+		default:
+			if !format.IsNumber(x) {
+				return parquet.Value{}, fmt.Errorf("%v (%T) is not a number", v, v)
+			}
+			s, _ = format.Text(x, "")
+		}
+		b, err := decimalBytes(s, f.Scale)
+		return parquet.FixedLenByteArrayValue(b), err
+	default:
+		s, _ := format.Text(v, "")
+		return parquet.ByteArrayValue([]byte(s)), nil
+	}
+	return parquet.Value{}, fmt.Errorf("%v (%T) cannot be converted to the column's type", v, v)
+}
+
+// decimalBytes returns the number as a 16 bytes, big-endian two's complement
+// integer, scaled by 10^scale (rounded half away from zero).
+func decimalBytes(s string, scale int) ([]byte, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("%q is not a number", s)
+	}
+	r.Mul(r, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil)))
+	num, denom := r.Num(), r.Denom()
+	q, m := new(big.Int).QuoRem(num, denom, new(big.Int))
+	if m.Sign() != 0 && new(big.Int).Mul(new(big.Int).Abs(m), big.NewInt(2)).Cmp(denom) >= 0 {
+		q.Add(q, big.NewInt(int64(num.Sign())))
+	}
+	if len(new(big.Int).Abs(q).String()) > decimalPrecision {
+		return nil, fmt.Errorf("%q overflows DECIMAL(%d, %d)", s, decimalPrecision, scale)
+	}
+	b := make([]byte, 16)
+	if q.Sign() >= 0 {
+		q.FillBytes(b)
+	} else {
+		// two's complement: 2^128 + q
+		new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 128), q).FillBytes(b)
+	}
+	return b, nil
+}
@@ -0,0 +1,252 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package parquet_test
+
+import (
+	"bytes"
+	"database/sql"
+	"io"
+	"math/big"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	pq "github.com/parquet-go/parquet-go"
+
+	"github.com/UNO-SOFT/spreadsheet"
+	"github.com/UNO-SOFT/spreadsheet/parquet"
+	"github.com/UNO-SOFT/spreadsheet/spreadsheettest"
+)
+
+func TestConformance(t *testing.T) {
+	spreadsheettest.Run(t, spreadsheettest.Config{
+		New: func(w io.Writer) (spreadsheet.Writer, error) { return parquet.NewZipWriter(w), nil },
+	})
+}
+
+type nopCloser struct{ *bytes.Buffer }
+
+func (nopCloser) Close() error { return nil }
+
+func TestSchemaOrder(t *testing.T) {
+	var buf bytes.Buffer
+	w := parquet.NewFilesWriter(func(string) (io.WriteCloser, error) { return nopCloser{&buf}, nil })
+	sheet, err := w.NewSheet("order", []spreadsheet.Column{{Name: "zeta"}, {Name: "alpha"}, {Name: "mid"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = sheet.AppendRow("z", 1, 2.5); err != nil {
+		t.Fatal(err)
+	}
+	if err = sheet.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f, err := pq.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, path := range f.Schema().Columns() {
+		names = append(names, path[0])
+	}
+	if want := []string{"zeta", "alpha", "mid"}; !slices.Equal(names, want) {
+		t.Errorf("got columns %q, wanted %q", names, want)
+	}
+	rows := make([]pq.Row, 1)
+	n, _ := f.RowGroups()[0].Rows().ReadRows(rows)
+	if n != 1 {
+		t.Fatalf("read %d rows", n)
+	}
+	if got := rows[0][0].String(); got != "z" {
+		t.Errorf("got %q in the first column, wanted %q", got, "z")
+	}
+}
+
+// writeFile writes the rows into one Parquet file, and opens it.
+func writeFile(t *testing.T, cols []spreadsheet.Column, rows [][]any, opts ...parquet.Option) *pq.File {
+	t.Helper()
+	var buf bytes.Buffer
+	w := parquet.NewFilesWriter(func(string) (io.WriteCloser, error) { return nopCloser{&buf}, nil }, opts...)
+	sheet, err := w.NewSheet("test", cols)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range rows {
+		if err = sheet.AppendRow(row...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = sheet.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f, err := pq.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+// readRows reads all the rows of all the row groups.
+func readRows(t *testing.T, f *pq.File) []pq.Row {
+	t.Helper()
+	var rows []pq.Row
+	for _, rg := range f.RowGroups() {
+		rr := rg.Rows()
+		buf := make([]pq.Row, 100)
+		for {
+			n, err := rr.ReadRows(buf)
+			for _, row := range buf[:n] {
+				rows = append(rows, row.Clone())
+			}
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatal(err)
+			}
+		}
+		rr.Close()
+	}
+	return rows
+}
+
+// decimal returns the unscaled value of the 16 bytes two's complement DECIMAL.
+func decimal(v pq.Value) string {
+	if v.IsNull() {
+		return "null"
+	}
+	b := v.ByteArray()
+	n := new(big.Int).SetBytes(b)
+	if b[0]&0x80 != 0 {
+		n.Sub(n, new(big.Int).Lsh(big.NewInt(1), 128))
+	}
+	return n.String()
+}
+
+func TestTypes(t *testing.T) {
+	when := time.Date(2026, 10, 19, 13, 14, 15, 123456000, time.UTC)
+	f := writeFile(t, []spreadsheet.Column{
+		{Name: "amount", Column: spreadsheet.Style{Format: "#,##0.00"}},
+		{Name: "inferred"},
+		{Name: "integer", Column: spreadsheet.Style{Format: "0"}},
+		{Name: "when"},
+		{Name: "count"},
+		{Name: "plain"},
+	}, [][]any{
+		{spreadsheet.Number("1.005"), spreadsheet.Number("12.345"), spreadsheet.Number("7"), when, sql.NullInt64{Int64: 3, Valid: true}, 1},
+		{spreadsheet.Number("-2.5"), spreadsheet.Number("-0.0005"), spreadsheet.Number("-7.5"), time.Time{}, sql.NullInt64{}, 2},
+		{spreadsheet.Number(""), spreadsheet.Number(strings.Repeat("9", 35) + ".999"), nil, nil, nil, 3},
+	})
+
+	for _, tc := range []struct {
+		Name      string
+		Scale     int
+		Optional  bool
+		Decimal   bool
+		Timestamp bool
+		Kind      pq.Kind
+	}{
+		{Name: "amount", Scale: 2, Optional: true, Decimal: true, Kind: pq.FixedLenByteArray},
+		{Name: "inferred", Scale: 3, Optional: true, Decimal: true, Kind: pq.FixedLenByteArray},
+		{Name: "integer", Scale: 0, Optional: true, Decimal: true, Kind: pq.FixedLenByteArray},
+		{Name: "when", Optional: true, Timestamp: true, Kind: pq.Int64},
+		{Name: "count", Optional: true, Kind: pq.Int64},
+		{Name: "plain", Kind: pq.Int64},
+	} {
+		field, ok := f.Schema().Lookup(tc.Name)
+		if !ok {
+			t.Errorf("no %s column", tc.Name)
+			continue
+		}
+		node := field.Node
+		if node.Optional() != tc.Optional || node.Type().Kind() != tc.Kind {
+			t.Errorf("%s: got optional=%t %v, wanted optional=%t %v", tc.Name, node.Optional(), node.Type().Kind(), tc.Optional, tc.Kind)
+		}
+		lt := node.Type().LogicalType()
+		if tc.Decimal {
+			if lt == nil || lt.Decimal == nil || int(lt.Decimal.Scale) != tc.Scale || lt.Decimal.Precision != 38 {
+				t.Errorf("%s: got %v, wanted DECIMAL(38, %d)", tc.Name, lt, tc.Scale)
+			}
+		}
+		if tc.Timestamp && (lt == nil || lt.Timestamp == nil || lt.Timestamp.Unit.Micros == nil) {
+			t.Errorf("%s: got %v, wanted TIMESTAMP(MICROS)", tc.Name, lt)
+		}
+	}
+
+	rows := readRows(t, f)
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows, wanted 3", len(rows))
+	}
+	got := make([][]string, len(rows))
+	for i, row := range rows {
+		got[i] = []string{decimal(row[0]), decimal(row[1]), decimal(row[2])}
+		for _, v := range row[3:] {
+			if v.IsNull() {
+				got[i] = append(got[i], "null")
+			} else {
+				got[i] = append(got[i], strconv.FormatInt(v.Int64(), 10))
+			}
+		}
+	}
+	want := [][]string{
+		{"101", "12345", "7", strconv.FormatInt(when.UnixMicro(), 10), "3", "1"},
+		{"-250", "-1", "-8", "null", "null", "2"},
+		{"null", strings.Repeat("9", 38), "null", "null", "null", "3"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	w := parquet.NewFilesWriter(func(string) (io.WriteCloser, error) { return nopCloser{new(bytes.Buffer)}, nil })
+	defer w.Close()
+	sheet, err := w.NewSheet("errors", []spreadsheet.Column{{Name: "plain"}, {Name: "amount"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sheet.Close()
+	if err = sheet.AppendRow(1, spreadsheet.Number("1")); err != nil {
+		t.Fatal(err)
+	}
+	if err = sheet.AppendRow(nil, spreadsheet.Number("2")); err == nil || !strings.Contains(err.Error(), "null") {
+		t.Errorf("got %+v for a null in an int column", err)
+	}
+	// 10^38 fits into 127 bits, but not into 38 digits
+	if err = sheet.AppendRow(3, spreadsheet.Number("1"+strings.Repeat("0", 38))); err == nil {
+		t.Error("39 digits fit into DECIMAL(38, 0)")
+	}
+	if err = sheet.AppendRow(4, spreadsheet.Number("-"+strings.Repeat("9", 38))); err != nil {
+		t.Errorf("38 digits: %+v", err)
+	}
+}
+
+func TestRowGroups(t *testing.T) {
+	rows := make([][]any, 2500)
+	for i := range rows {
+		rows[i] = []any{i}
+	}
+	f := writeFile(t, []spreadsheet.Column{{Name: "i"}}, rows, parquet.WithRowGroupSize(1000))
+	var sizes []int64
+	for _, rg := range f.RowGroups() {
+		sizes = append(sizes, rg.NumRows())
+	}
+	if want := []int64{1000, 1000, 500}; !slices.Equal(sizes, want) {
+		t.Errorf("got row groups of %d rows, wanted %d", sizes, want)
+	}
+	for i, row := range readRows(t, f) {
+		if got := row[0].Int64(); got != int64(i) {
+			t.Fatalf("%d. got %d", i, got)
+		}
+	}
+}
@@ -0,0 +1,357 @@
+// Copyright 2021, 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pdf
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/johnfercher/maroto/v2"
+	"github.com/johnfercher/maroto/v2/pkg/components/page"
+	"github.com/johnfercher/maroto/v2/pkg/components/row"
+	"github.com/johnfercher/maroto/v2/pkg/components/text"
+	"github.com/johnfercher/maroto/v2/pkg/config"
+	"github.com/johnfercher/maroto/v2/pkg/consts/align"
+	"github.com/johnfercher/maroto/v2/pkg/consts/fontstyle"
+	"github.com/johnfercher/maroto/v2/pkg/consts/orientation"
+	"github.com/johnfercher/maroto/v2/pkg/consts/pagesize"
+	"github.com/johnfercher/maroto/v2/pkg/core"
+	"github.com/johnfercher/maroto/v2/pkg/props"
+
+	"github.com/UNO-SOFT/spreadsheet"
+	"github.com/UNO-SOFT/spreadsheet/internal/format"
+)
+
+var _ = (spreadsheet.Writer)((*PDFWriter)(nil))
+
+func init() {
+	spreadsheet.Register(spreadsheet.Format{
+		Name: "pdf", Extensions: []string{".pdf"}, MIMETypes: []string{"application/pdf"},
+		New: func(w io.Writer, o spreadsheet.Options) (spreadsheet.Writer, error) {
+			return NewWriter(w, WithTitle(o.Title)), nil
+		},
+	})
+}
+
+// Option is an option for the PDF writer.
+type Option func(*options)
+
+type options struct {
+	title, subject   string
+	alternateColor   Color
+	fontSize         float64
+	landscape        bool
+	printPageNumbers bool
+}
+
+// WithFontSize sets the font size of the content (default 8).
+func WithFontSize(size float64) Option { return func(o *options) { o.fontSize = size } }
+
+// WithLandscape sets landscape orientation (default portrait).
+func WithLandscape(landscape bool) Option { return func(o *options) { o.landscape = landscape } }
+
+// WithPageNumbers sets whether to print page numbers.
+func WithPageNumbers(print bool) Option { return func(o *options) { o.printPageNumbers = print } }
+
+// WithAlternateColor sets the background color of the header and every second row.
+func WithAlternateColor(c Color) Option { return func(o *options) { o.alternateColor = c } }
+
+// WithTitle sets the title of the document.
+func WithTitle(title string) Option { return func(o *options) { o.title = title } }
+
+// WithSubject sets the subject of the document.
+func WithSubject(subject string) Option { return func(o *options) { o.subject = subject } }
+
+// DefaultAlternateColor is the default alternate background color.
+var DefaultAlternateColor = Color{Red: 230, Green: 230, Blue: 230}
+
+// PDFWriter writes the sheets as a PDF document, one section per sheet.
+type PDFWriter struct {
+	w      io.Writer
+	sheets []*PDFSheet
+	opts   options
+	mu     sync.Mutex
+}
+
+// NewWriter returns a spreadsheet.Writer that writes a PDF document into w.
+//
+// This writer allows concurrent writes to separate sheets.
+//
+// This writer collects everything in memory, and renders the document on Close.
+func NewWriter(w io.Writer, opts ...Option) *PDFWriter {
+	o := options{fontSize: 8, alternateColor: DefaultAlternateColor}
+	for _, f := range opts {
+		f(&o)
+	}
+	return &PDFWriter{w: w, opts: o}
+}
+
+// NewSheet starts a new section, with the column names in the header.
+func (pw *PDFWriter) NewSheet(name string, cols []spreadsheet.Column) (spreadsheet.Sheet, error) {
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+	if pw.w == nil {
+		return nil, os.ErrClosed
+	}
+	sheet := &PDFSheet{Name: name, cols: cols}
+	pw.sheets = append(pw.sheets, sheet)
+	return sheet, nil
+}
+
+// Close renders the PDF and writes it to the underlying io.Writer.
+//
+// Does not close the underlying io.Writer.
+func (pw *PDFWriter) Close() error {
+	if pw == nil {
+		return nil
+	}
+	pw.mu.Lock()
+	defer pw.mu.Unlock()
+	w, sheets := pw.w, pw.sheets
+	pw.w, pw.sheets = nil, nil
+	if w == nil {
+		return nil
+	}
+	maxGridSize := 100
+	for _, s := range sheets {
+		s.mu.Lock()
+		maxGridSize = max(maxGridSize, s.columnCount())
+		s.mu.Unlock()
+	}
+
+	orient := orientation.Vertical
+	if pw.opts.landscape {
+		orient = orientation.Horizontal
+	}
+	cfg := config.NewBuilder().
+		WithCompression(true).
+		WithCreationDate(time.Now()).
+		WithCreator("UNO-SOFT/spreadsheet/pdf", false).
+		WithBottomMargin(1).
+		WithTopMargin(1).
+		WithLeftMargin(1).
+		WithRightMargin(1).
+		WithPageSize(pagesize.A4).
+		WithOrientation(orient).
+		WithMaxGridSize(maxGridSize)
+	if pw.opts.title != "" {
+		cfg = cfg.WithTitle(pw.opts.title, true)
+	}
+	if pw.opts.subject != "" {
+		cfg = cfg.WithSubject(pw.opts.subject, true)
+	}
+	if pw.opts.printPageNumbers {
+		cfg = cfg.WithPageNumber(props.PageNumber{
+			Pattern: "{current}/{total}", Place: props.LeftBottom})
+	}
+	m := maroto.NewMetricsDecorator(maroto.New(cfg.Build()))
+	for _, s := range sheets {
+		s.mu.Lock()
+		rows := s.rows(&pw.opts, maxGridSize)
+		s.mu.Unlock()
+		m.AddPages(page.New().Add(rows...))
+	}
+
+	doc, err := m.Generate()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(doc.GetBytes())
+	return err
+}
+
+type cell struct {
+	Text string
+	Kind format.Kind
+}
+
+// PDFSheet is a section of the PDF document.
+type PDFSheet struct {
+	Name    string
+	cols    []spreadsheet.Column
+	content [][]cell
+	mu      sync.Mutex
+}
+
+// AppendRow appends a row to the section, the values formatted according to the column's style.
+func (ps *PDFSheet) AppendRow(values ...any) error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	row := make([]cell, len(values))
+	for i, v := range values {
+		var style spreadsheet.Style
+		if i < len(ps.cols) {
+			style = ps.cols[i].Column
+		}
+		row[i].Text, row[i].Kind = format.Text(v, style.Format)
+	}
+	ps.content = append(ps.content, row)
+	return nil
+}
+
+// Close the sheet.
+func (ps *PDFSheet) Close() error { return nil }
+
+func (ps *PDFSheet) columnCount() int {
+	n := len(ps.cols)
+	for _, row := range ps.content {
+		n = max(n, len(row))
+	}
+	return n
+}
+
+func (ps *PDFSheet) hasHeader() bool {
+	for _, c := range ps.cols {
+		if c.Name != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// gridSizes returns the column sizes, proportional to the average text length,
+// summing up to maxGridSize.
+func (ps *PDFSheet) gridSizes(maxGridSize int) []int {
+	widths := make([]float64, ps.columnCount())
+	if ps.hasHeader() {
+		for i, c := range ps.cols {
+			widths[i] += float64(utf8.RuneCountInString(c.Name))
+		}
+	}
+	for _, row := range ps.content {
+		for i, c := range row {
+			widths[i] += float64(utf8.RuneCountInString(c.Text))
+		}
+	}
+	var sum float64
+	for i, w := range widths {
+		widths[i] = max(w, 1)
+		sum += widths[i]
+	}
+	sizes := make([]int, len(widths))
+	var total, widest int
+	for i, w := range widths {
+		sizes[i] = max(1, int(math.Round(w/sum*float64(maxGridSize))))
+		total += sizes[i]
+		if sizes[i] > sizes[widest] {
+			widest = i
+		}
+	}
+	if len(sizes) != 0 && sizes[widest]-(total-maxGridSize) >= 1 {
+		sizes[widest] -= total - maxGridSize
+	}
+	return sizes
+}
+
+// rows returns the rows of the section.
+func (ps *PDFSheet) rows(opts *options, maxGridSize int) []core.Row {
+	bgColor := props.Color{Red: 255, Green: 255, Blue: 255}
+	fgColor := props.Color{}
+	alternateBgColor := opts.alternateColor.Color()
+	alternateFgColor := opts.alternateColor.Foreground().Color()
+	sizes := ps.gridSizes(maxGridSize)
+
+	var rows []core.Row
+	if ps.Name != "" {
+		rows = append(rows, text.NewRow(opts.fontSize*2, ps.Name,
+			props.Text{Family: "arial", Style: fontstyle.Bold, Size: opts.fontSize * 1.75}))
+	}
+
+	if ps.hasHeader() {
+		headerCols := make([]core.Col, len(sizes))
+		for i := range headerCols {
+			headerProps := props.Text{Family: "arial", Size: opts.fontSize * 1.375, Align: align.Center, Color: &alternateFgColor}
+			var name string
+			if i < len(ps.cols) {
+				name = ps.cols[i].Name
+				if ps.cols[i].Header.FontBold {
+					headerProps.Style = fontstyle.Bold
+				}
+			}
+			headerCols[i] = text.NewCol(sizes[i], name, headerProps)
+		}
+		rows = append(rows, row.New(opts.fontSize*1.375*7/8).Add(headerCols...).
+			WithStyle(&props.Cell{BackgroundColor: &alternateBgColor}))
+	}
+
+	type rowProp struct {
+		props.Text
+		props.Cell
+	}
+	contentProps := []rowProp{
+		{
+			Text: props.Text{Family: "courier", Size: opts.fontSize, Color: &fgColor},
+			Cell: props.Cell{BackgroundColor: &bgColor},
+		},
+		{
+			Text: props.Text{Family: "courier", Size: opts.fontSize, Color: &alternateFgColor},
+			Cell: props.Cell{BackgroundColor: &alternateBgColor},
+		},
+	}
+	for i, cc := range ps.content {
+		rp := &contentProps[i%2]
+		content := make([]core.Col, len(sizes))
+		for j := range content {
+			tp := rp.Text
+			var s string
+			if j < len(cc) {
+				s = cc[j].Text
+				if cc[j].Kind == format.Number {
+					tp.Align = align.Right
+				}
+			}
+			if j < len(ps.cols) && ps.cols[j].Column.FontBold {
+				tp.Style = fontstyle.Bold
+			}
+			content[j] = text.NewCol(sizes[j], s, tp)
+		}
+		rows = append(rows, row.New(opts.fontSize).Add(content...).WithStyle(&rp.Cell))
+	}
+	return rows
+}
+
+// Color is a RGB color.
+type Color struct {
+	Red, Green, Blue uint8
+}
+
+func (c Color) String() string {
+	return fmt.Sprintf("%02x%02x%02x", c.Red, c.Green, c.Blue)
+}
+
+// Parse the hex RRGGBB representation.
+func (c *Color) Parse(s string) error {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	if len(b) != 3 {
+		return fmt.Errorf("%q: wanted RRGGBB", s)
+	}
+	c.Red, c.Green, c.Blue = b[0], b[1], b[2]
+	return nil
+}
+
+// Foreground returns black for light, and white for dark colors.
+func (c Color) Foreground() Color {
+	// 0.2989 R + 0.5870 G + 0.1140 B
+	if 0.2989*float64(c.Red)+
+		0.5870*float64(c.Green)+
+		0.1140*float64(c.Blue) > 127 {
+		return Color{}
+	}
+	return Color{Red: 255, Green: 255, Blue: 255}
+}
+
+// Color returns the color as maroto's props.Color.
+func (c Color) Color() props.Color {
+	return props.Color{Red: int(c.Red), Green: int(c.Green), Blue: int(c.Blue)}
+}
@@ -0,0 +1,20 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package pdf_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/UNO-SOFT/spreadsheet"
+	"github.com/UNO-SOFT/spreadsheet/pdf"
+	"github.com/UNO-SOFT/spreadsheet/spreadsheettest"
+)
+
+func TestConformance(t *testing.T) {
+	spreadsheettest.Run(t, spreadsheettest.Config{
+		New: func(w io.Writer) (spreadsheet.Writer, error) { return pdf.NewWriter(w), nil },
+	})
+}
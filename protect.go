@@ -0,0 +1,32 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package spreadsheet
+
+// Protector is implemented by the Writers that can protect the workbook and its sheets.
+type Protector interface {
+	// ProtectWorkbook protects the structure of the workbook:
+	// the sheets can not be added, removed, renamed or moved.
+	//
+	// It should be called before the first NewSheet.
+	ProtectWorkbook(password string) error
+	// ProtectSheet protects the cells of the named sheet,
+	// except the cells of the columns with Unlocked style.
+	//
+	// It should be called before the NewSheet creating the sheet.
+	ProtectSheet(name string, p SheetProtection) error
+}
+
+// SheetProtection is the protection of a sheet.
+//
+// The selection of the cells is always allowed,
+// the writers ignore the allowed actions that their format can not express.
+type SheetProtection struct {
+	// Password is needed to remove the protection - without it, anyone can remove it.
+	Password string
+
+	AllowSort, AllowFilter                 bool
+	AllowInsertRows, AllowDeleteRows       bool
+	AllowInsertColumns, AllowDeleteColumns bool
+}
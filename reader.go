@@ -0,0 +1,230 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package spreadsheet
+
+import (
+	"context"
+	"database/sql"
+	"encoding"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Reader reads the sheets of a spreadsheet.
+type Reader interface {
+	// Sheets returns the names of the sheets.
+	Sheets() []string
+	// ReadRows calls fn with each row of the sheet, with the cell values as text:
+	// numbers in their plain decimal form, dates as "2006-01-02" or
+	// "2006-01-02T15:04:05" (or as date serial numbers, where the format stores them so),
+	// booleans as "true"/"false" (or "1"/"0").
+	//
+	// The row slice is reused, fn must copy it to keep it.
+	ReadRows(ctx context.Context, sheetName string, fn func(row []string) error) error
+}
+
+// CellError is an error of a cell, the row and column are zero-based.
+type CellError struct {
+	Err      error
+	Sheet    string
+	Row, Col int
+}
+
+// Ref returns the reference of the cell, such as "Sheet1!C17".
+func (e *CellError) Ref() string {
+	return e.Sheet + "!" + ColumnName(e.Col) + strconv.Itoa(e.Row+1)
+}
+
+func (e *CellError) Error() string { return e.Ref() + ": " + e.Err.Error() }
+func (e *CellError) Unwrap() error { return e.Err }
+
+// ErrNoHeader is returned when the sheet has no header row.
+var ErrNoHeader = errors.New("no header row")
+
+// ReadStructs reads the sheet into a slice of T - a struct, or a pointer to a struct.
+//
+// The first row is the header: the cells are matched case-insensitively
+// with the column names of ColumnsOf[T] (so with the same struct tags),
+// the unmatched cells and fields are ignored.
+//
+// The cells are converted to the field types: the numbers are parsed,
+// time.Time accepts dates in ISO 8601 format and date serial numbers,
+// bool accepts what strconv.ParseBool accepts,
+// and sql.Scanner and encoding.TextUnmarshaler implementations are used.
+// Empty cells leave the fields at their zero values.
+//
+// A conversion error is returned as *CellError.
+func ReadStructs[T any](ctx context.Context, r Reader, sheetName string) ([]T, error) {
+	t := reflect.TypeFor[T]()
+	p, err := planOf(t)
+	if err != nil {
+		return nil, err
+	}
+	var fields []int
+	var result []T
+	var rowNum int
+	err = r.ReadRows(ctx, sheetName, func(row []string) error {
+		rowNum++
+		if fields == nil {
+			fields = make([]int, len(row))
+			for i, name := range row {
+				fields[i] = -1
+				for j, c := range p.cols {
+					if strings.EqualFold(strings.TrimSpace(name), c.Name) {
+						fields[i] = j
+						break
+					}
+				}
+			}
+			return nil
+		}
+		var s T
+		v := reflect.ValueOf(&s).Elem()
+		if p.ptr {
+			v.Set(reflect.New(t.Elem()))
+			v = v.Elem()
+		}
+		for i, text := range row {
+			if i >= len(fields) || fields[i] < 0 || text == "" {
+				continue
+			}
+			f, err := fieldByIndex(v, p.fields[fields[i]])
+			if err == nil {
+				err = setField(f, text)
+			}
+			if err != nil {
+				return &CellError{Sheet: sheetName, Row: rowNum - 1, Col: i, Err: err}
+			}
+		}
+		result = append(result, s)
+		return nil
+	})
+	if err == nil && fields == nil {
+		err = fmt.Errorf("%s: %w", sheetName, ErrNoHeader)
+	}
+	return result, err
+}
+
+// fieldByIndex returns the field, allocating the nil embedded pointers.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return v, fmt.Errorf("cannot set nil embedded pointer to unexported struct %v", v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
+
+var (
+	typeScanner         = reflect.TypeFor[sql.Scanner]()
+	typeTextUnmarshaler = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// setField sets the field from the cell's text.
+func setField(f reflect.Value, text string) error {
+	if f.Kind() == reflect.Pointer {
+		if f.IsNil() {
+			f.Set(reflect.New(f.Type().Elem()))
+		}
+		return setField(f.Elem(), text)
+	}
+	if f.Type() == typeTime {
+		t, err := parseTime(text)
+		if err == nil {
+			f.Set(reflect.ValueOf(t))
+		}
+		return err
+	}
+	if pt := reflect.PointerTo(f.Type()); pt.Implements(typeScanner) {
+		scanner := f.Addr().Interface().(sql.Scanner)
+		err := scanner.Scan(text)
+		if err != nil {
+			// sql.NullTime and the like scan only time.Time
+			if t, tErr := parseTime(text); tErr == nil && scanner.Scan(t) == nil {
+				return nil
+			}
+		}
+		return err
+	} else if pt.Implements(typeTextUnmarshaler) {
+		return f.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text))
+	}
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(text)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		f.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(text, 10, f.Type().Bits())
+		if err != nil {
+			// integral floats, such as "3.0" or "1e3"
+			fl, fErr := strconv.ParseFloat(text, 64)
+			if fErr != nil || fl != math.Trunc(fl) || f.OverflowInt(int64(fl)) {
+				return err
+			}
+			i = int64(fl)
+		}
+		f.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(text, 10, f.Type().Bits())
+		if err != nil {
+			fl, fErr := strconv.ParseFloat(text, 64)
+			if fErr != nil || fl < 0 || fl != math.Trunc(fl) || f.OverflowUint(uint64(fl)) {
+				return err
+			}
+			u = uint64(fl)
+		}
+		f.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		fl, err := strconv.ParseFloat(text, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+		f.SetFloat(fl)
+	case reflect.Slice:
+		if f.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("unsupported field type %v", f.Type())
+		}
+		f.SetBytes([]byte(text))
+	default:
+		return fmt.Errorf("unsupported field type %v", f.Type())
+	}
+	return nil
+}
+
+// dateEpoch is the zero of the spreadsheet date serial numbers.
+var dateEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// parseTime parses the ISO 8601 date (and time), or the date serial number.
+func parseTime(text string) (time.Time, error) {
+	if serial, err := strconv.ParseFloat(text, 64); err == nil {
+		days := math.Floor(serial)
+		return dateEpoch.AddDate(0, 0, int(days)).Add(
+			time.Duration(math.Round((serial-days)*86400)) * time.Second), nil
+	}
+	for _, layout := range []string{
+		"2006-01-02", time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02 15:04:05.999999999",
+	} {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is not a date", text)
+}
@@ -0,0 +1,125 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package spreadsheet_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/UNO-SOFT/spreadsheet"
+)
+
+// memReader reads the sheets from memory.
+type memReader map[string][][]string
+
+func (r memReader) Sheets() []string {
+	names := make([]string, 0, len(r))
+	for name := range r {
+		names = append(names, name)
+	}
+	return names
+}
+
+func (r memReader) ReadRows(ctx context.Context, sheetName string, fn func(row []string) error) error {
+	rows, ok := r[sheetName]
+	if !ok {
+		return fmt.Errorf("sheet %q not found", sheetName)
+	}
+	for _, row := range rows {
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type Base struct {
+	ID int64 `spreadsheet:"id"`
+}
+
+type record struct {
+	*Base
+	Name    string
+	Amount  float64 `spreadsheet:"amount"`
+	Count   uint8
+	Active  bool
+	Day     time.Time
+	Seen    sql.NullTime
+	Note    sql.NullString
+	Opt     *int
+	Addr    netip.Addr
+	Data    []byte
+	Skipped string `spreadsheet:"-"`
+}
+
+func TestReadStructs(t *testing.T) {
+	r := memReader{"Sheet1": {
+		{"ID", " name ", "AMOUNT", "count", "active", "day", "seen", "note", "opt", "addr", "data", "skipped", "unknown"},
+		{"1", "first", "1.5", "3.0", "true", "2026-10-19", "2026-10-19T12:34:56", "a note", "7", "127.0.0.1", "bytes", "x", "y"},
+		{"2", "", "", "", "0", "46314.5", "46314", "", "", "", ""},
+		nil,
+	}}
+	got, err := spreadsheet.ReadStructs[record](context.Background(), r, "Sheet1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	seven := 7
+	want := []record{
+		{
+			Base: &Base{ID: 1}, Name: "first", Amount: 1.5, Count: 3, Active: true,
+			Day:  time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			Seen: sql.NullTime{Time: time.Date(2026, 10, 19, 12, 34, 56, 0, time.UTC), Valid: true},
+			Note: sql.NullString{String: "a note", Valid: true},
+			Opt:  &seven, Addr: netip.MustParseAddr("127.0.0.1"), Data: []byte("bytes"),
+		},
+		{
+			Base: &Base{ID: 2},
+			Day:  time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+			Seen: sql.NullTime{Time: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), Valid: true},
+		},
+		{},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got\n%+v\nwanted\n%+v", got, want)
+	}
+
+	ptrs, err := spreadsheet.ReadStructs[*record](context.Background(), r, "Sheet1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ptrs) != len(want) || !reflect.DeepEqual(*ptrs[0], want[0]) {
+		t.Errorf("got %+v, wanted pointers to %+v", ptrs, want)
+	}
+}
+
+func TestReadStructsErrors(t *testing.T) {
+	r := memReader{
+		"Bad":   {{"name", "count"}, {"a", "1"}, {"b", "256"}},
+		"Empty": nil,
+	}
+	_, err := spreadsheet.ReadStructs[record](context.Background(), r, "Bad")
+	var ce *spreadsheet.CellError
+	if !errors.As(err, &ce) {
+		t.Fatalf("got %v, wanted a CellError", err)
+	}
+	if got, want := ce.Ref(), "Bad!B3"; got != want {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+	if _, err = spreadsheet.ReadStructs[record](context.Background(), r, "Empty"); !errors.Is(err, spreadsheet.ErrNoHeader) {
+		t.Errorf("got %v, wanted %v", err, spreadsheet.ErrNoHeader)
+	}
+	if _, err = spreadsheet.ReadStructs[string](context.Background(), r, "Bad"); !errors.Is(err, spreadsheet.ErrNotStruct) {
+		t.Errorf("got %v, wanted %v", err, spreadsheet.ErrNotStruct)
+	}
+	if _, err = spreadsheet.ReadStructs[record](context.Background(), r, "Missing"); err == nil {
+		t.Error("no error for a missing sheet")
+	}
+}
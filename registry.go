@@ -0,0 +1,134 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package spreadsheet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Format is an output format, registered by the format packages
+// (import them for their side effect, such as
+//
+//	import _ "github.com/UNO-SOFT/spreadsheet/ods"
+//
+// to make them available).
+type Format struct {
+	// New returns a new Writer writing into w.
+	New func(w io.Writer, opts Options) (Writer, error)
+	// Name is the short name of the format, such as "ods".
+	Name string
+	// Extensions are the file name extensions (with the leading dot),
+	// the first is the preferred one.
+	Extensions []string
+	// MIMETypes are the MIME types (without parameters),
+	// the first is the preferred one.
+	MIMETypes []string
+}
+
+// Options are the format-independent writer options -
+// the formats use what they support, and ignore the rest.
+type Options struct {
+	// Title is the document title.
+	Title string
+	// Encoding is the character encoding of the text formats (see GetEncoding).
+	Encoding string
+}
+
+// Option is a format-independent writer option.
+type Option func(*Options)
+
+// WithTitle sets the document title.
+func WithTitle(title string) Option { return func(o *Options) { o.Title = title } }
+
+// WithEncoding sets the character encoding of the text formats.
+func WithEncoding(encName string) Option { return func(o *Options) { o.Encoding = encName } }
+
+var (
+	formatsMu sync.RWMutex
+	formats   []Format
+)
+
+// Register the format - a later registration of the same name replaces the earlier.
+func Register(f Format) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+	if i := slices.IndexFunc(formats, func(g Format) bool { return g.Name == f.Name }); i >= 0 {
+		formats[i] = f
+		return
+	}
+	formats = append(formats, f)
+}
+
+// Formats returns the registered formats, sorted by name.
+func Formats() []Format {
+	formatsMu.RLock()
+	fs := slices.Clone(formats)
+	formatsMu.RUnlock()
+	slices.SortFunc(fs, func(a, b Format) int { return strings.Compare(a.Name, b.Name) })
+	return fs
+}
+
+// LookupFormat returns the format for the name, which may be
+//   - a format name ("ods"),
+//   - an extension (".ods"), or a file name ("out/report.ods"),
+//   - a MIME type, possibly with parameters ("text/csv; charset=utf-8").
+//
+// The extension is matched before the MIME type, as a file path contains slashes, too.
+func LookupFormat(name string) (Format, bool) {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	lower := strings.ToLower(strings.TrimSpace(name))
+	for _, f := range formats {
+		if f.Name == lower {
+			return f, true
+		}
+	}
+	if filepath.Ext(lower) != "" {
+		// the longest matching extension wins, so ".tar.gz"-like extensions are possible
+		var found Format
+		var length int
+		for _, f := range formats {
+			for _, ext := range f.Extensions {
+				if len(ext) > length && strings.HasSuffix(lower, strings.ToLower(ext)) {
+					found, length = f, len(ext)
+				}
+			}
+		}
+		if length != 0 {
+			return found, true
+		}
+	}
+	if mediaType, _, err := mime.ParseMediaType(lower); err == nil && strings.Contains(mediaType, "/") {
+		for _, f := range formats {
+			if slices.Contains(f.MIMETypes, mediaType) {
+				return f, true
+			}
+		}
+	}
+	return Format{}, false
+}
+
+// ErrUnknownFormat is returned for an unregistered format.
+var ErrUnknownFormat = errors.New("unknown format")
+
+// NewWriterFor returns a new Writer for the format found by LookupFormat(name).
+func NewWriterFor(name string, w io.Writer, opts ...Option) (Writer, error) {
+	f, ok := LookupFormat(name)
+	if !ok {
+		return nil, fmt.Errorf("%q: %w", name, ErrUnknownFormat)
+	}
+	var o Options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return f.New(w, o)
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package spreadsheet_test
+
+import (
+	"testing"
+
+	"github.com/UNO-SOFT/spreadsheet"
+	_ "github.com/UNO-SOFT/spreadsheet/csv"
+	_ "github.com/UNO-SOFT/spreadsheet/json"
+	_ "github.com/UNO-SOFT/spreadsheet/ods"
+	_ "github.com/UNO-SOFT/spreadsheet/xlsx"
+)
+
+func TestLookupFormat(t *testing.T) {
+	for _, tc := range []struct {
+		Name, Want string
+	}{
+		{"ods", "ods"},
+		{" XLSX ", "xlsx"},
+		{".ods", "ods"},
+		{"report.ods", "ods"},
+		{"./report.ods", "ods"},
+		{"out/report.xlsx", "xlsx"},
+		{"/tmp/out/Report.XLSX", "xlsx"},
+		{"dir.v2/report.fods", "fods"},
+		{"data.jsonl", "ndjson"},
+		{"text/csv", "csv"},
+		{"text/csv; charset=utf-8", "csv"},
+		{"application/vnd.oasis.opendocument.spreadsheet", "ods"},
+		{"application/vnd.oasis.opendocument.spreadsheet-flat-xml", "fods"},
+		{"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "xlsx"},
+		{"application/x-unknown", ""},
+		{"out/report.unknown", ""},
+		{"report", ""},
+		{"", ""},
+	} {
+		f, ok := spreadsheet.LookupFormat(tc.Name)
+		if ok != (tc.Want != "") || f.Name != tc.Want {
+			t.Errorf("%q: got %q (%t), wanted %q", tc.Name, f.Name, ok, tc.Want)
+		}
+	}
+}
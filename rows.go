@@ -0,0 +1,191 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package spreadsheet
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WriteRowsOptions are the options of WriteRows.
+type WriteRowsOptions struct {
+	// Header is the style of the header (column names).
+	Header Style
+	// DateFormat is the format of the DATE columns (default "yyyy-mm-dd").
+	DateFormat string
+	// DateTimeFormat is the format of the TIMESTAMP and DATETIME columns
+	// (default "yyyy-mm-dd hh:mm:ss").
+	DateTimeFormat string
+	// SplitSheets continues the export in a new sheet ("name (2)", "name (3)", ...)
+	// when the sheet is full (AppendRow returns ErrTooManyRows),
+	// instead of returning the error.
+	SplitSheets bool
+}
+
+// WriteRows writes the rows as a new sheet (or sheets, see WriteRowsOptions.SplitSheets)
+// into w, and returns the number of rows written.
+//
+// The columns are built from rows.ColumnTypes(): the names are the column names,
+// numbers with scale get a number format with that many decimal places
+// (and are written as Number, without loss of precision),
+// DATE, DATETIME and TIMESTAMP columns get a date format.
+//
+// The rows are not closed.
+func WriteRows(ctx context.Context, w Writer, sheetName string, rows *sql.Rows, opts WriteRowsOptions) (int64, error) {
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return 0, err
+	}
+	if opts.DateFormat == "" {
+		opts.DateFormat = "yyyy-mm-dd"
+	}
+	if opts.DateTimeFormat == "" {
+		opts.DateTimeFormat = "yyyy-mm-dd hh:mm:ss"
+	}
+	cols := make([]Column, len(types))
+	holders := make([]any, len(types))
+	for i, t := range types {
+		cols[i] = Column{Name: t.Name(), Header: opts.Header}
+		cols[i].Column.Format, holders[i] = columnHolder(t, &opts)
+	}
+
+	sheet, err := w.NewSheet(sheetName, cols)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if sheet != nil {
+			sheet.Close()
+		}
+	}()
+	values := make([]any, len(holders))
+	var n int64
+	for seq := 1; rows.Next(); {
+		if err = ctx.Err(); err != nil {
+			return n, err
+		}
+		if err = rows.Scan(holders...); err != nil {
+			return n, fmt.Errorf("scan row %d: %w", n+1, err)
+		}
+		for i, h := range holders {
+			values[i] = holderValue(h)
+		}
+		err = sheet.AppendRow(values...)
+		if err != nil && opts.SplitSheets && errors.Is(err, ErrTooManyRows) {
+			if err = sheet.Close(); err != nil {
+				return n, err
+			}
+			seq++
+			if sheet, err = w.NewSheet(sheetName+" ("+strconv.Itoa(seq)+")", cols); err != nil {
+				return n, err
+			}
+			err = sheet.AppendRow(values...)
+		}
+		if err != nil {
+			return n, err
+		}
+		n++
+	}
+	if err = rows.Err(); err != nil {
+		return n, err
+	}
+	err = sheet.Close()
+	sheet = nil
+	return n, err
+}
+
+var (
+	typeTime  = reflect.TypeOf(time.Time{})
+	typeBytes = reflect.TypeOf([]byte(nil))
+)
+
+// columnHolder returns the number format of the column, and the holder to scan into.
+func columnHolder(t *sql.ColumnType, opts *WriteRowsOptions) (string, any) {
+	dbType := strings.ToUpper(t.DatabaseTypeName())
+	switch {
+	case strings.Contains(dbType, "TIMESTAMP") || strings.Contains(dbType, "DATETIME"):
+		return opts.DateTimeFormat, new(sql.NullTime)
+	case strings.Contains(dbType, "DATE"):
+		return opts.DateFormat, new(sql.NullTime)
+	}
+	if _, scale, ok := t.DecimalSize(); ok && scale > 0 && scale < 64 {
+		return "0." + strings.Repeat("0", int(scale)), new(numberHolder)
+	}
+	st := t.ScanType()
+	if st == nil {
+		return "", new(any)
+	}
+	if st.Kind() == reflect.Pointer {
+		st = st.Elem()
+	}
+	// the sql.Null* types
+	if st.Kind() == reflect.Struct && st != typeTime && st.NumField() == 2 && st.Field(1).Name == "Valid" {
+		st = st.Field(0).Type
+	}
+	switch st.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return "", new(sql.NullInt64)
+	case reflect.Float32, reflect.Float64:
+		return "", new(sql.NullFloat64)
+	case reflect.Bool:
+		return "", new(sql.NullBool)
+	case reflect.String:
+		return "", new(sql.NullString)
+	}
+	switch {
+	case st == typeTime:
+		return opts.DateTimeFormat, new(sql.NullTime)
+	case st.ConvertibleTo(typeBytes):
+		return "", new([]byte)
+	}
+	return "", new(any)
+}
+
+// numberHolder scans a decimal number as text.
+type numberHolder struct{ sql.NullString }
+
+// holderValue returns the scanned value, nil for NULL.
+func holderValue(h any) any {
+	switch x := h.(type) {
+	case *sql.NullInt64:
+		if x.Valid {
+			return x.Int64
+		}
+	case *sql.NullFloat64:
+		if x.Valid {
+			return x.Float64
+		}
+	case *sql.NullBool:
+		if x.Valid {
+			return x.Bool
+		}
+	case *sql.NullString:
+		if x.Valid {
+			return x.String
+		}
+	case *sql.NullTime:
+		if x.Valid {
+			return x.Time
+		}
+	case *numberHolder:
+		if x.Valid {
+			return Number(x.String)
+		}
+	case *[]byte:
+		if *x != nil {
+			return *x
+		}
+	case *any:
+		return *x
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package spreadsheet_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/UNO-SOFT/spreadsheet"
+)
+
+// fakeDB is a database/sql driver, returning the same rows for every query.
+type fakeDB struct {
+	cols []fakeColumn
+	rows [][]driver.Value
+}
+
+type fakeColumn struct {
+	Name, DBType string
+	ScanType     reflect.Type
+	Scale        int64
+}
+
+func (db *fakeDB) Connect(context.Context) (driver.Conn, error) { return db, nil }
+func (db *fakeDB) Driver() driver.Driver                        { return nil }
+func (db *fakeDB) Prepare(string) (driver.Stmt, error)          { return db, nil }
+func (db *fakeDB) Close() error                                 { return nil }
+func (db *fakeDB) Begin() (driver.Tx, error)                    { return nil, errors.New("no transactions") }
+func (db *fakeDB) NumInput() int                                { return -1 }
+func (db *fakeDB) Exec([]driver.Value) (driver.Result, error)   { return nil, errors.New("no exec") }
+func (db *fakeDB) Query([]driver.Value) (driver.Rows, error)    { return &fakeRows{db: db}, nil }
+
+type fakeRows struct {
+	db *fakeDB
+	i  int
+}
+
+func (r *fakeRows) Columns() []string {
+	names := make([]string, len(r.db.cols))
+	for i, c := range r.db.cols {
+		names[i] = c.Name
+	}
+	return names
+}
+func (r *fakeRows) Close() error { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.i >= len(r.db.rows) {
+		return io.EOF
+	}
+	copy(dest, r.db.rows[r.i])
+	r.i++
+	return nil
+}
+func (r *fakeRows) ColumnTypeDatabaseTypeName(i int) string { return r.db.cols[i].DBType }
+func (r *fakeRows) ColumnTypeScanType(i int) reflect.Type   { return r.db.cols[i].ScanType }
+func (r *fakeRows) ColumnTypePrecisionScale(i int) (int64, int64, bool) {
+	return 38, r.db.cols[i].Scale, r.db.cols[i].Scale != 0
+}
+
+func (db *fakeDB) query(t *testing.T) *sql.Rows {
+	t.Helper()
+	rows, err := sql.OpenDB(db).QueryContext(context.Background(), "SELECT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { rows.Close() })
+	return rows
+}
+
+func TestWriteRows(t *testing.T) {
+	day := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	db := fakeDB{
+		cols: []fakeColumn{
+			{"id", "INTEGER", reflect.TypeFor[int64](), 0},
+			{"name", "VARCHAR", reflect.TypeFor[sql.NullString](), 0},
+			{"amount", "NUMBER", reflect.TypeFor[string](), 2},
+			{"ratio", "DOUBLE", reflect.TypeFor[float64](), 0},
+			{"ok", "BOOLEAN", reflect.TypeFor[bool](), 0},
+			{"day", "DATE", reflect.TypeFor[time.Time](), 0},
+			{"at", "TIMESTAMP", reflect.TypeFor[time.Time](), 0},
+			{"data", "BLOB", reflect.TypeFor[[]byte](), 0},
+		},
+		rows: [][]driver.Value{
+			{int64(1), "a", "1.50", 0.5, true, day, day.Add(time.Hour), []byte("x")},
+			{nil, nil, nil, nil, nil, nil, nil, nil},
+		},
+	}
+	var w memWriter
+	n, err := spreadsheet.WriteRows(context.Background(), &w, "Data", db.query(t), spreadsheet.WriteRowsOptions{
+		Header: spreadsheet.Style{FontBold: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("got %d rows, wanted 2", n)
+	}
+	sheet := w.sheets["Data"]
+	if sheet == nil || !sheet.closed {
+		t.Fatalf("no closed sheet: %+v", w.sheets)
+	}
+	var formats []string
+	for _, c := range sheet.Cols {
+		if !c.Header.FontBold {
+			t.Errorf("%s: no bold header", c.Name)
+		}
+		formats = append(formats, c.Column.Format)
+	}
+	if got, want := strings.Join(formats, "|"), "||0.00|||yyyy-mm-dd|yyyy-mm-dd hh:mm:ss|"; got != want {
+		t.Errorf("got formats %q, wanted %q", got, want)
+	}
+	want := [][]any{
+		{int64(1), "a", spreadsheet.Number("1.50"), 0.5, true, day, day.Add(time.Hour), []byte("x")},
+		{nil, nil, nil, nil, nil, nil, nil, nil},
+	}
+	if !reflect.DeepEqual(sheet.Rows, want) {
+		t.Errorf("got %#v, wanted %#v", sheet.Rows, want)
+	}
+}
+
+func TestWriteRowsSplitSheets(t *testing.T) {
+	db := fakeDB{cols: []fakeColumn{{"n", "INTEGER", reflect.TypeFor[int64](), 0}}}
+	for i := range 5 {
+		db.rows = append(db.rows, []driver.Value{int64(i)})
+	}
+
+	w := memWriter{maxRows: 2}
+	n, err := spreadsheet.WriteRows(context.Background(), &w, "Data", db.query(t), spreadsheet.WriteRowsOptions{})
+	if !errors.Is(err, spreadsheet.ErrTooManyRows) {
+		t.Errorf("got %v, wanted %v", err, spreadsheet.ErrTooManyRows)
+	}
+	if n != 2 {
+		t.Errorf("got %d rows, wanted 2", n)
+	}
+
+	w = memWriter{maxRows: 2}
+	if n, err = spreadsheet.WriteRows(context.Background(), &w, "Data", db.query(t), spreadsheet.WriteRowsOptions{SplitSheets: true}); err != nil {
+		t.Fatal(err)
+	}
+	if n != 5 {
+		t.Errorf("got %d rows, wanted 5", n)
+	}
+	for name, want := range map[string][][]any{
+		"Data":     {{int64(0)}, {int64(1)}},
+		"Data (2)": {{int64(2)}, {int64(3)}},
+		"Data (3)": {{int64(4)}},
+	} {
+		sheet := w.sheets[name]
+		if sheet == nil {
+			t.Errorf("no sheet %q", name)
+			continue
+		}
+		if !sheet.closed {
+			t.Errorf("%s: not closed", name)
+		}
+		if !reflect.DeepEqual(sheet.Rows, want) {
+			t.Errorf("%s: got %v, wanted %v", name, sheet.Rows, want)
+		}
+	}
+	if len(w.sheets) != 3 {
+		t.Errorf("got %d sheets, wanted 3", len(w.sheets))
+	}
+}
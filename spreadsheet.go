@@ -6,6 +6,7 @@ package spreadsheet
 
 import (
 	"errors"
+	"fmt"
 	"io"
 )
 
@@ -31,14 +32,51 @@ type Style struct {
 	Format string
 	// FontBold is true if the font is bold
 	FontBold bool
+	// Unlocked cells stay editable when the sheet is protected (see Protector).
+	Unlocked bool
+	// FontColor and BackgroundColor are "#RRGGBB" colors, the default if empty.
+	FontColor, BackgroundColor string
 }
 
-// Column contains the Name of the column and header's style and column's style.
+// ErrInvalidStyle is returned for a Style with an invalid color.
+var ErrInvalidStyle = errors.New("invalid style")
+
+// Check the colors of the style.
+func (s Style) Check() error {
+	for _, c := range [...]string{s.FontColor, s.BackgroundColor} {
+		if c != "" && !isColor(c) {
+			return fmt.Errorf("%w: color %q is not #RRGGBB", ErrInvalidStyle, c)
+		}
+	}
+	return nil
+}
+
+// Column contains the Name of the column and header's style and column's style,
+// and the optional Validation of the column's cells.
 type Column struct {
+	// Validation is the rule of the cells below the header.
+	// The ods writer streams the sheets only if the first sheet has no Validations,
+	// and then rejects the Validations of the later sheets.
+	Validation *Validation
+	// Groups are the group headers above the Name, from the top level:
+	// the adjacent columns' same groups are merged into one cell, styled as the first column's Header.
+	// The writers that can not merge cells ignore them.
+	Groups         []string
 	Name           string
 	Header, Column Style
 }
 
+// Check the Header and Column styles of the column.
+func (c Column) Check() error {
+	if err := c.Header.Check(); err != nil {
+		return fmt.Errorf("%s header: %w", c.Name, err)
+	}
+	if err := c.Column.Check(); err != nil {
+		return fmt.Errorf("%s: %w", c.Name, err)
+	}
+	return nil
+}
+
 var ErrTooManyRows = errors.New("too many rows")
 
 // Number is a string that contains a number.
@@ -0,0 +1,327 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package spreadsheetml
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	qt "github.com/valyala/quicktemplate"
+
+	"github.com/UNO-SOFT/spreadsheet"
+	"github.com/UNO-SOFT/spreadsheet/internal/format"
+	"github.com/UNO-SOFT/spreadsheet/internal/spool"
+)
+
+//go:generate qtc
+
+var _ = (spreadsheet.Writer)((*XMLWriter)(nil))
+
+func init() {
+	spreadsheet.Register(spreadsheet.Format{
+		Name: "spreadsheetml", Extensions: []string{".xml"}, MIMETypes: []string{"application/vnd.ms-excel"},
+		New: func(w io.Writer, _ spreadsheet.Options) (spreadsheet.Writer, error) {
+			xw, err := NewWriter(w)
+			if err != nil {
+				return nil, err
+			}
+			return xw, nil
+		},
+	})
+}
+
+// MaxRowCount is the maximum number of rows of Excel 2003.
+const MaxRowCount = 65536
+
+// XMLWriter writes an Excel 2003 XML Spreadsheet (urn:schemas-microsoft-com:office:spreadsheet).
+type XMLWriter struct {
+	w      io.Writer
+	spool  *spool.Spool
+	head   *spool.Part
+	styles map[spreadsheet.Style]string
+	list   []style
+	// names are the lower case sheet names, as Excel ignores the case
+	names map[string]struct{}
+	mu    sync.Mutex
+}
+
+// NewWriter returns a spreadsheet.Writer that writes an XML Spreadsheet into w.
+//
+// As the styles precede the worksheets, the worksheets are buffered in temporary files,
+// and written when the writer is closed.
+//
+// This writer allows concurrent writes to separate sheets.
+func NewWriter(w io.Writer) (*XMLWriter, error) {
+	sp := spool.New(w)
+	head, err := sp.NewPart(nil)
+	if err != nil {
+		return nil, err
+	}
+	return &XMLWriter{w: w, spool: sp, head: head}, nil
+}
+
+type style struct {
+	ID, Format string
+	Bold       bool
+}
+
+// Close the XMLWriter: writes the styles and copies the worksheets.
+//
+// Does not close the underlying io.Writer.
+func (xw *XMLWriter) Close() error {
+	if xw == nil {
+		return nil
+	}
+	xw.mu.Lock()
+	defer xw.mu.Unlock()
+	w, sp, head := xw.w, xw.spool, xw.head
+	xw.w, xw.spool, xw.head = nil, nil, nil
+	if w == nil {
+		return nil
+	}
+	W := qt.AcquireWriter(head)
+	streambeginWorkbook(W, xw.list)
+	qt.ReleaseWriter(W)
+	if err := head.Close(); err != nil {
+		return err
+	}
+	if err := sp.Close(); err != nil {
+		return err
+	}
+	W = qt.AcquireWriter(w)
+	streamendWorkbook(W)
+	qt.ReleaseWriter(W)
+	return nil
+}
+
+// getStyleID returns the ID of the style, registering it when needed.
+//
+// Must be called with xw.mu held.
+func (xw *XMLWriter) getStyleID(st spreadsheet.Style) string {
+	if !st.FontBold && st.Format == "" {
+		return ""
+	}
+	if id, ok := xw.styles[st]; ok {
+		return id
+	}
+	if xw.styles == nil {
+		xw.styles = make(map[spreadsheet.Style]string)
+	}
+	id := "s" + strconv.Itoa(len(xw.styles)+1)
+	xw.styles[st] = id
+	xw.list = append(xw.list, style{ID: id, Format: st.Format, Bold: st.FontBold})
+	return id
+}
+
+// NewSheet returns a new worksheet.
+//
+// The characters Excel does not allow in sheet names ([]:*?/\) are replaced,
+// and the name is cut to 31 characters. An empty name becomes "Sheet<n>",
+// and a name already used gets a " (2)", " (3)"... suffix.
+func (xw *XMLWriter) NewSheet(name string, cols []spreadsheet.Column) (spreadsheet.Sheet, error) {
+	xw.mu.Lock()
+	defer xw.mu.Unlock()
+	if xw.spool == nil {
+		return nil, os.ErrClosed
+	}
+	part, err := xw.spool.NewPart(nil)
+	if err != nil {
+		return nil, err
+	}
+	xs := &XMLSheet{Name: xw.uniqueName(sheetName(name)), xw: xw, part: part, w: qt.AcquireWriter(part),
+		cols: cols, colStyles: make([]string, len(cols))}
+	for i, c := range cols {
+		xs.colStyles[i] = xw.getStyleID(c.Column)
+	}
+	var header []cell
+	for _, c := range cols {
+		if c.Name != "" {
+			header = make([]cell, len(cols))
+			for i, c := range cols {
+				header[i] = cell{Type: "String", Value: xmlText(c.Name), StyleID: xw.getStyleID(c.Header)}
+			}
+			xs.rowCount++
+			break
+		}
+	}
+	streambeginSheet(xs.w, xs.Name, xs.colStyles, header)
+	return xs, nil
+}
+
+type cell struct {
+	Type, Value, StyleID string
+}
+
+// XMLSheet is a worksheet.
+type XMLSheet struct {
+	xw        *XMLWriter
+	part      *spool.Part
+	w         *qt.Writer
+	Name      string
+	cols      []spreadsheet.Column
+	colStyles []string
+	cells     []cell
+	rowCount  int
+	mu        sync.Mutex
+}
+
+// AppendRow appends a row of typed cells: numbers, DateTime (time.Time),
+// Boolean and String.
+func (xs *XMLSheet) AppendRow(values ...any) error {
+	xs.mu.Lock()
+	defer xs.mu.Unlock()
+	if xs.w == nil {
+		return os.ErrClosed
+	}
+	if xs.rowCount >= MaxRowCount {
+		return spreadsheet.ErrTooManyRows
+	}
+	xs.xw.mu.Lock()
+	closed := xs.xw.w == nil
+	xs.xw.mu.Unlock()
+	if closed { // the styles are written
+		return os.ErrClosed
+	}
+	xs.cells = xs.cells[:0]
+	for i, v := range values {
+		var c cell
+		if i < len(xs.colStyles) {
+			c.StyleID = xs.colStyles[i]
+		}
+		switch x := format.Value(v).(type) {
+		case nil:
+		case time.Time:
+			if x.IsZero() {
+				break
+			}
+			c.Type, c.Value = "DateTime", x.Format("2006-01-02T15:04:05.000")
+			var style spreadsheet.Style
+			if i < len(xs.cols) {
+				style = xs.cols[i].Column
+			}
+			if !format.IsDateFormat(style.Format) {
+				// a DateTime without date format is shown as a number
+				style.Format = "yyyy\\-mm\\-dd"
+				if x.Hour() != 0 || x.Minute() != 0 || x.Second() != 0 {
+					style.Format = "yyyy\\-mm\\-dd\\ hh:mm:ss"
+				}
+				xs.xw.mu.Lock()
+				if xs.xw.w == nil {
+					xs.xw.mu.Unlock()
+					return os.ErrClosed
+				}
+				c.StyleID = xs.xw.getStyleID(style)
+				xs.xw.mu.Unlock()
+			}
+		case bool:
+			c.Type, c.Value = "Boolean", "0"
+			if x {
+				c.Value = "1"
+			}
+		case float64:
+			c.Type, c.Value = numberType(x), strconv.FormatFloat(x, 'g', -1, 64)
+		case float32:
+			c.Type, c.Value = numberType(float64(x)), strconv.FormatFloat(float64(x), 'g', -1, 32)
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			c.Type, c.Value = "Number", fmt.Sprintf("%d", x)
+		case spreadsheet.Number:
+			if x == "" {
+				break
+			}
+			c.Type, c.Value = "String", xmlText(string(x))
+			if f, err := strconv.ParseFloat(string(x), 64); err == nil {
+				c.Type = numberType(f)
+			}
+		default:
+			c.Type = "String"
+			c.Value, _ = format.Text(x, "")
+			c.Value = xmlText(c.Value)
+		}
+		xs.cells = append(xs.cells, c)
+	}
+	streamrow(xs.w, xs.cells)
+	xs.rowCount++
+	return nil
+}
+
+// Close the worksheet.
+func (xs *XMLSheet) Close() error {
+	if xs == nil {
+		return nil
+	}
+	xs.mu.Lock()
+	defer xs.mu.Unlock()
+	W, part := xs.w, xs.part
+	xs.w, xs.part = nil, nil
+	if W == nil {
+		return nil
+	}
+	streamendSheet(W)
+	qt.ReleaseWriter(W)
+	return part.Close()
+}
+
+// numberType returns the type of the float's cell: NaN and ±Inf are not Numbers.
+func numberType(f float64) string {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "String"
+	}
+	return "Number"
+}
+
+// xmlText drops the characters XML does not allow.
+func xmlText(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' || 0x20 <= r && r <= 0xD7FF ||
+			0xE000 <= r && r <= 0xFFFD || 0x10000 <= r && r <= 0x10FFFF {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// uniqueName returns the name, or "Sheet<n>" if it is empty,
+// with a " (n)" suffix if it is already used.
+//
+// Must be called with xw.mu held.
+func (xw *XMLWriter) uniqueName(name string) string {
+	if name == "" {
+		name = "Sheet" + strconv.Itoa(len(xw.names)+1)
+	}
+	if xw.names == nil {
+		xw.names = make(map[string]struct{})
+	}
+	base := []rune(name)
+	for n := 2; ; n++ {
+		k := strings.ToLower(name)
+		if _, ok := xw.names[k]; !ok {
+			xw.names[k] = struct{}{}
+			return name
+		}
+		suffix := " (" + strconv.Itoa(n) + ")"
+		name = string(base[:min(len(base), 31-len(suffix))]) + suffix
+	}
+}
+
+// sheetName returns the name without the characters Excel does not allow, at most 31 characters long.
+func sheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, xmlText(name))
+	if utf8.RuneCountInString(name) > 31 {
+		name = string([]rune(name)[:31])
+	}
+	return name
+}
@@ -0,0 +1,303 @@
+// Code generated by qtc from "spreadsheetml.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:1
+package spreadsheetml
+
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:1
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:1
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:1
+func streambeginWorkbook(qw422016 *qt422016.Writer, styles []style) {
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:1
+	qw422016.N().S(`<?xml version="1.0" encoding="UTF-8"?>
+<?mso-application progid="Excel.Sheet"?>
+<Workbook xmlns="urn:schemas-microsoft-com:office:spreadsheet" xmlns:o="urn:schemas-microsoft-com:office:office" xmlns:x="urn:schemas-microsoft-com:office:excel" xmlns:ss="urn:schemas-microsoft-com:office:spreadsheet" xmlns:html="http://www.w3.org/TR/REC-html40">
+ <Styles>
+  <Style ss:ID="Default" ss:Name="Normal"/>
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:6
+	for _, s := range styles {
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:6
+		qw422016.N().S(`  <Style ss:ID="`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:6
+		qw422016.E().S(s.ID)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:6
+		qw422016.N().S(`">`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:7
+		if s.Bold {
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:7
+			qw422016.N().S(`<Font ss:Bold="1"/>`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:7
+		}
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:8
+		if s.Format != "" {
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:8
+			qw422016.N().S(`<NumberFormat ss:Format="`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:8
+			qw422016.E().S(s.Format)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:8
+			qw422016.N().S(`"/>`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:8
+		}
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:8
+		qw422016.N().S(`</Style>
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:9
+	}
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:9
+	qw422016.N().S(` </Styles>
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:10
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:10
+func writebeginWorkbook(qq422016 qtio422016.Writer, styles []style) {
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:10
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:10
+	streambeginWorkbook(qw422016, styles)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:10
+	qt422016.ReleaseWriter(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:10
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:10
+func beginWorkbook(styles []style) string {
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:10
+	qb422016 := qt422016.AcquireByteBuffer()
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:10
+	writebeginWorkbook(qb422016, styles)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:10
+	qs422016 := string(qb422016.B)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:10
+	qt422016.ReleaseByteBuffer(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:10
+	return qs422016
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:10
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:12
+func streambeginSheet(qw422016 *qt422016.Writer, name string, colStyles []string, header []cell) {
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:12
+	qw422016.N().S(` <Worksheet ss:Name="`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:12
+	qw422016.E().S(name)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:12
+	qw422016.N().S(`">
+  <Table>
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:14
+	for _, s := range colStyles {
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:14
+		qw422016.N().S(`   <Column`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:14
+		if s != "" {
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:14
+			qw422016.N().S(` ss:StyleID="`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:14
+			qw422016.E().S(s)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:14
+			qw422016.N().S(`"`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:14
+		}
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:14
+		qw422016.N().S(`/>
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:15
+	}
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:15
+	if len(header) != 0 {
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:15
+		streamrow(qw422016, header)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:15
+	}
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:15
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:15
+func writebeginSheet(qq422016 qtio422016.Writer, name string, colStyles []string, header []cell) {
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:15
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:15
+	streambeginSheet(qw422016, name, colStyles, header)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:15
+	qt422016.ReleaseWriter(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:15
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:15
+func beginSheet(name string, colStyles []string, header []cell) string {
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:15
+	qb422016 := qt422016.AcquireByteBuffer()
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:15
+	writebeginSheet(qb422016, name, colStyles, header)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:15
+	qs422016 := string(qb422016.B)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:15
+	qt422016.ReleaseByteBuffer(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:15
+	return qs422016
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:15
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:17
+func streamrow(qw422016 *qt422016.Writer, cells []cell) {
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:17
+	qw422016.N().S(`   <Row>`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:17
+	for _, c := range cells {
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:18
+		if c.Type == "" {
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:18
+			qw422016.N().S(`<Cell`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:18
+			if c.StyleID != "" {
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:18
+				qw422016.N().S(` ss:StyleID="`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:18
+				qw422016.E().S(c.StyleID)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:18
+				qw422016.N().S(`"`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:18
+			}
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:18
+			qw422016.N().S(`/>`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:19
+		} else {
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:19
+			qw422016.N().S(`<Cell`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:19
+			if c.StyleID != "" {
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:19
+				qw422016.N().S(` ss:StyleID="`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:19
+				qw422016.E().S(c.StyleID)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:19
+				qw422016.N().S(`"`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:19
+			}
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:19
+			qw422016.N().S(`><Data ss:Type="`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:19
+			qw422016.E().S(c.Type)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:19
+			qw422016.N().S(`">`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:19
+			qw422016.E().S(c.Value)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:19
+			qw422016.N().S(`</Data></Cell>`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:20
+		}
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:20
+	}
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:20
+	qw422016.N().S(`</Row>
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:21
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:21
+func writerow(qq422016 qtio422016.Writer, cells []cell) {
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:21
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:21
+	streamrow(qw422016, cells)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:21
+	qt422016.ReleaseWriter(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:21
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:21
+func row(cells []cell) string {
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:21
+	qb422016 := qt422016.AcquireByteBuffer()
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:21
+	writerow(qb422016, cells)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:21
+	qs422016 := string(qb422016.B)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:21
+	qt422016.ReleaseByteBuffer(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:21
+	return qs422016
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:21
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:23
+func streamendSheet(qw422016 *qt422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:23
+	qw422016.N().S(`  </Table>
+ </Worksheet>
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:25
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:25
+func writeendSheet(qq422016 qtio422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:25
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:25
+	streamendSheet(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:25
+	qt422016.ReleaseWriter(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:25
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:25
+func endSheet() string {
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:25
+	qb422016 := qt422016.AcquireByteBuffer()
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:25
+	writeendSheet(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:25
+	qs422016 := string(qb422016.B)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:25
+	qt422016.ReleaseByteBuffer(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:25
+	return qs422016
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:25
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:27
+func streamendWorkbook(qw422016 *qt422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:27
+	qw422016.N().S(`</Workbook>
+`)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:28
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:28
+func writeendWorkbook(qq422016 qtio422016.Writer) {
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:28
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:28
+	streamendWorkbook(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:28
+	qt422016.ReleaseWriter(qw422016)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:28
+}
+
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:28
+func endWorkbook() string {
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:28
+	qb422016 := qt422016.AcquireByteBuffer()
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:28
+	writeendWorkbook(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:28
+	qs422016 := string(qb422016.B)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:28
+	qt422016.ReleaseByteBuffer(qb422016)
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:28
+	return qs422016
+//line src/github.com/UNO-SOFT/spreadsheet/spreadsheetml/spreadsheetml.qtpl:28
+}
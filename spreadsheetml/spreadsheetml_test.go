@@ -0,0 +1,135 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package spreadsheetml_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/UNO-SOFT/spreadsheet"
+	"github.com/UNO-SOFT/spreadsheet/spreadsheetml"
+	"github.com/UNO-SOFT/spreadsheet/spreadsheettest"
+)
+
+func TestConformance(t *testing.T) {
+	spreadsheettest.Run(t, spreadsheettest.Config{
+		New:     func(w io.Writer) (spreadsheet.Writer, error) { return spreadsheetml.NewWriter(w) },
+		MaxRows: spreadsheetml.MaxRowCount,
+	})
+}
+
+func TestSanitize(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := spreadsheetml.NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sheet, err := w.NewSheet("a[b]:c*d?e/f\\g \x01 and a very long name", []spreadsheet.Column{{Name: "ctrl\x02"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = sheet.AppendRow(math.NaN(), float32(math.Inf(1)), spreadsheet.Number("-Inf"), "bell\x07\uFFFE", 1.5); err != nil {
+		t.Fatal(err)
+	}
+	if err = sheet.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	type data struct {
+		Type  string `xml:"Type,attr"`
+		Value string `xml:",chardata"`
+	}
+	var wb struct {
+		Worksheets []struct {
+			Name string `xml:"Name,attr"`
+			Rows []struct {
+				Data []data `xml:"Cell>Data"`
+			} `xml:"Table>Row"`
+		} `xml:"Worksheet"`
+	}
+	if err = xml.Unmarshal(buf.Bytes(), &wb); err != nil {
+		t.Fatalf("%+v\n%s", err, buf.Bytes())
+	}
+	if len(wb.Worksheets) != 1 || len(wb.Worksheets[0].Rows) != 2 {
+		t.Fatalf("got %+v", wb)
+	}
+	ws := wb.Worksheets[0]
+	if want := "a_b__c_d_e_f_g  and a very long"; ws.Name != want {
+		t.Errorf("got sheet name %q, wanted %q", ws.Name, want)
+	}
+	if got, want := ws.Rows[0].Data, []data{{"String", "ctrl"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got header %q, wanted %q", got, want)
+	}
+	want := []data{{"String", "NaN"}, {"String", "+Inf"}, {"String", "-Inf"}, {"String", "bell"}, {"Number", "1.5"}}
+	if got := ws.Rows[1].Data; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+func TestSheetNames(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := spreadsheetml.NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	long := strings.Repeat("x", 40)
+	for _, name := range []string{"a/b", `A\b`, "", long, long} {
+		sheet, err := w.NewSheet(name, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = sheet.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var wb struct {
+		Worksheets []struct {
+			Name string `xml:"Name,attr"`
+		} `xml:"Worksheet"`
+	}
+	if err = xml.Unmarshal(buf.Bytes(), &wb); err != nil {
+		t.Fatalf("%+v\n%s", err, buf.Bytes())
+	}
+	var names []string
+	for _, ws := range wb.Worksheets {
+		names = append(names, ws.Name)
+	}
+	if want := []string{"a_b", "A_b (2)", "Sheet3", long[:31], long[:27] + " (2)"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("got %q, wanted %q", names, want)
+	}
+}
+
+func TestAppendAfterClose(t *testing.T) {
+	w, err := spreadsheetml.NewWriter(io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sheet, err := w.NewSheet("late", []spreadsheet.Column{{Name: "day"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// the styles are already written, a date would need a new one
+	if err = sheet.AppendRow(time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("got %+v, wanted %v", err, os.ErrClosed)
+	}
+}
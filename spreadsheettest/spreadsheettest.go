@@ -0,0 +1,691 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package spreadsheettest provides a conformance suite for the
+// spreadsheet.Writer implementations.
+//
+// A writer package runs it from its tests:
+//
+//	func TestConformance(t *testing.T) {
+//		spreadsheettest.Run(t, spreadsheettest.Config{
+//			New: func(w io.Writer) (spreadsheet.Writer, error) { return ods.NewWriter(w) },
+//			Read: func(b []byte) (spreadsheet.Reader, error) {
+//				return ods.NewReader(bytes.NewReader(b), int64(len(b)))
+//			},
+//			MaxRows: ods.MaxRowCount,
+//		})
+//	}
+package spreadsheettest
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/UNO-SOFT/spreadsheet"
+)
+
+// Config configures the conformance suite.
+type Config struct {
+	// New returns a new writer, writing into w.
+	New func(w io.Writer) (spreadsheet.Writer, error)
+	// Read returns a reader for the written data, to verify the round-trip.
+	// Nil skips the round-trip verification.
+	Read func(data []byte) (spreadsheet.Reader, error)
+	// MaxRows is the maximum number of rows (including the header row) in a sheet,
+	// after which AppendRow must return spreadsheet.ErrTooManyRows.
+	// Zero skips the test, and it is skipped in -short mode for more than 100 000 rows.
+	MaxRows int
+	// SingleSheet is true if the format holds only one sheet
+	// (the round-trip verification reads only the first sheet).
+	SingleSheet bool
+	// DropsEmptyRows is true if the empty rows are not read back
+	// (such as the blank lines of a CSV), so the round-trip verification ignores them.
+	DropsEmptyRows bool
+	// Style returns the style of the cell (zero-based, the header is the row 0)
+	// of the sheet in the written data, to verify the bold font and the colors.
+	// Nil skips the style verification.
+	Style func(data []byte, sheetName string, row, col int) (spreadsheet.Style, error)
+}
+
+// HostileStrings are the strings that are problematic in some formats:
+// markup, quotes, separators, whitespace, non-ASCII characters,
+// and the control characters and non-characters that XML does not allow.
+var HostileStrings = []string{
+	`<b>&amp;</b>`, `]]>`, `"quoted", 'single'`, `a,b;c|d`, "tab\there",
+	"new\nline", "  leading and trailing  ", `back\slash`, `=1+2`,
+	"árvíztűrő tükörfúrógép", "日本語のテキスト", "עברית", "emoji 🎉👍🏽",
+	"control\x01\x08\x1bchars", "non-characters \uFFFE\uFFFF",
+}
+
+// Run the conformance suite.
+func Run(t *testing.T, cfg Config) {
+	t.Run("MultipleSheets", func(t *testing.T) { testMultipleSheets(t, cfg) })
+	t.Run("Concurrent", func(t *testing.T) { testConcurrent(t, cfg) })
+	t.Run("ValueTypes", func(t *testing.T) { testValueTypes(t, cfg) })
+	t.Run("Styles", func(t *testing.T) { testStyles(t, cfg) })
+	t.Run("EmptySheets", func(t *testing.T) { testEmptySheets(t, cfg) })
+	t.Run("HostileStrings", func(t *testing.T) { testHostileStrings(t, cfg) })
+	t.Run("TooManyRows", func(t *testing.T) { testTooManyRows(t, cfg) })
+	t.Run("Protection", func(t *testing.T) { testProtection(t, cfg) })
+	t.Run("Validation", func(t *testing.T) { testValidation(t, cfg) })
+	t.Run("ConditionalFormat", func(t *testing.T) { testConditionalFormat(t, cfg) })
+	t.Run("MergeCells", func(t *testing.T) { testMergeCells(t, cfg) })
+}
+
+// sheet is the expected content of a sheet.
+type sheet struct {
+	Name string
+	Cols []spreadsheet.Column
+	Rows [][]any
+}
+
+// write the sheets (concurrently, if concurrent is true), and return the written data.
+func write(t *testing.T, cfg Config, sheets []sheet, concurrent bool) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := cfg.New(&buf)
+	if err != nil {
+		t.Fatal("New:", err)
+	}
+	writeSheet := func(s sheet, sh spreadsheet.Sheet) error {
+		for i, row := range s.Rows {
+			if err := sh.AppendRow(row...); err != nil {
+				return fmt.Errorf("%s: AppendRow[%d]: %w", s.Name, i, err)
+			}
+		}
+		if err := sh.Close(); err != nil {
+			return fmt.Errorf("%s: Close: %w", s.Name, err)
+		}
+		return nil
+	}
+	if concurrent {
+		// create the sheets in order, as their order is part of the output
+		shs := make([]spreadsheet.Sheet, len(sheets))
+		for i, s := range sheets {
+			if shs[i], err = w.NewSheet(s.Name, s.Cols); err != nil {
+				t.Fatalf("NewSheet(%q): %+v", s.Name, err)
+			}
+		}
+		var wg sync.WaitGroup
+		errs := make([]error, len(sheets))
+		for i, s := range sheets {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = writeSheet(s, shs[i])
+			}()
+		}
+		wg.Wait()
+		if err = errors.Join(errs...); err != nil {
+			t.Fatal(err)
+		}
+	} else {
+		for _, s := range sheets {
+			sh, err := w.NewSheet(s.Name, s.Cols)
+			if err != nil {
+				t.Fatalf("NewSheet(%q): %+v", s.Name, err)
+			}
+			if err = writeSheet(s, sh); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal("Close:", err)
+	}
+	if err = w.Close(); err != nil {
+		t.Error("second Close:", err)
+	}
+	if buf.Len() == 0 {
+		t.Error("nothing has been written")
+	}
+	return buf.Bytes()
+}
+
+// verify the round-trip of the sheets, if cfg.Read is set.
+//
+// Only the string, integer and nil values are verified, as the formats
+// differ in the representation of the other types.
+func verify(t *testing.T, cfg Config, data []byte, sheets []sheet) {
+	t.Helper()
+	if cfg.Read == nil {
+		return
+	}
+	r, err := cfg.Read(data)
+	if err != nil {
+		t.Fatal("Read:", err)
+	}
+	if c, ok := r.(io.Closer); ok {
+		defer c.Close()
+	}
+	names := r.Sheets()
+	if cfg.SingleSheet {
+		sheets = sheets[:min(1, len(sheets))]
+	} else {
+		want := make([]string, len(sheets))
+		for i, s := range sheets {
+			want[i] = s.Name
+		}
+		if !slices.Equal(names, want) {
+			t.Errorf("got sheets %q, wanted %q", names, want)
+			return
+		}
+	}
+	ctx := context.Background()
+	for i, s := range sheets {
+		var got [][]string
+		if err := r.ReadRows(ctx, names[i], func(row []string) error {
+			got = append(got, slices.Clone(row))
+			return nil
+		}); err != nil {
+			t.Errorf("ReadRows(%q): %+v", s.Name, err)
+			continue
+		}
+		want := s.Rows
+		if hasHeader(s.Cols) {
+			header := make([]any, len(s.Cols))
+			for j, c := range s.Cols {
+				header[j] = c.Name
+			}
+			want = append([][]any{header}, want...)
+		}
+		if cfg.DropsEmptyRows {
+			want = slices.DeleteFunc(slices.Clone(want), isEmpty)
+		}
+		// the trailing empty rows may be omitted
+		for len(got) < len(want) && isEmpty(want[len(got)]) {
+			got = append(got, nil)
+		}
+		if len(got) != len(want) {
+			t.Errorf("%s: got %d rows, wanted %d", s.Name, len(got), len(want))
+			continue
+		}
+		for j, row := range want {
+			for k, v := range row {
+				var cell string
+				if k < len(got[j]) {
+					cell = got[j][k]
+				}
+				if err := check(cell, v); err != nil {
+					ref := spreadsheet.CellError{Sheet: s.Name, Row: j, Col: k, Err: err}
+					t.Error(ref.Error())
+				}
+			}
+		}
+	}
+}
+
+func hasHeader(cols []spreadsheet.Column) bool {
+	return slices.ContainsFunc(cols, func(c spreadsheet.Column) bool { return c.Name != "" })
+}
+
+func isEmpty(row []any) bool {
+	return !slices.ContainsFunc(row, func(v any) bool { return v != nil })
+}
+
+// check the read cell against the written value.
+func check(cell string, v any) error {
+	switch x := v.(type) {
+	case nil:
+		if cell != "" {
+			return fmt.Errorf("got %q, wanted empty", cell)
+		}
+	case string:
+		// the XML formats replace or drop the characters XML does not allow
+		if cell != x && cell != xmlSafe(x, '\uFFFD') && cell != xmlSafe(x, -1) {
+			return fmt.Errorf("got %q, wanted %q", cell, x)
+		}
+	case int:
+		if f, err := strconv.ParseFloat(cell, 64); err != nil || f != float64(x) {
+			return fmt.Errorf("got %q, wanted %d", cell, x)
+		}
+	}
+	return nil
+}
+
+// xmlSafe replaces the characters XML does not allow with repl, or drops them if repl is negative.
+func xmlSafe(s string, repl rune) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' || 0x20 <= r && r <= 0xD7FF ||
+			0xE000 <= r && r <= 0xFFFD || 0x10000 <= r && r <= 0x10FFFF {
+			return r
+		}
+		return repl
+	}, s)
+}
+
+func testMultipleSheets(t *testing.T, cfg Config) {
+	sheets := make([]sheet, 3)
+	for i := range sheets {
+		s := &sheets[i]
+		s.Name = "Sheet" + strconv.Itoa(i+1)
+		s.Cols = []spreadsheet.Column{{Name: "id"}, {Name: "name"}}
+		for j := range 10 * (i + 1) {
+			s.Rows = append(s.Rows, []any{j, s.Name + "-" + strconv.Itoa(j)})
+		}
+	}
+	verify(t, cfg, write(t, cfg, sheets, false), sheets)
+}
+
+func testConcurrent(t *testing.T, cfg Config) {
+	sheets := make([]sheet, 8)
+	for i := range sheets {
+		s := &sheets[i]
+		s.Name = "Concurrent" + strconv.Itoa(i+1)
+		s.Cols = []spreadsheet.Column{{Name: "sheet"}, {Name: "row"}}
+		for j := range 500 {
+			s.Rows = append(s.Rows, []any{i, j})
+		}
+	}
+	verify(t, cfg, write(t, cfg, sheets, true), sheets)
+}
+
+func testValueTypes(t *testing.T, cfg Config) {
+	now := time.Date(2026, 1, 2, 15, 4, 5, 0, time.Local)
+	day := time.Date(2026, 1, 2, 0, 0, 0, 0, time.Local)
+	values := []struct {
+		Name  string
+		Value any
+	}{
+		{"string", "text"},
+		{"int", 42},
+		{"int8", int8(-8)},
+		{"int64", int64(math.MaxInt64)},
+		{"uint8", uint8(255)},
+		{"uint64", uint64(math.MaxUint64)},
+		{"float32", float32(1.5)},
+		{"float64", 3.14159},
+		{"bool", true},
+		{"time", now},
+		{"date", day},
+		{"zero time", time.Time{}},
+		{"Number", spreadsheet.Number("12345678901234567890.123")},
+		{"empty Number", spreadsheet.Number("")},
+		{"NullString", sql.NullString{String: "null string", Valid: true}},
+		{"invalid NullString", sql.NullString{}},
+		{"NullInt64", sql.NullInt64{Int64: -64, Valid: true}},
+		{"NullFloat64", sql.NullFloat64{Float64: 6.4, Valid: true}},
+		{"NullTime", sql.NullTime{Time: now, Valid: true}},
+		{"invalid NullTime", sql.NullTime{}},
+		{"nil", nil},
+	}
+	cols := make([]spreadsheet.Column, len(values))
+	row := make([]any, len(values))
+	for i, v := range values {
+		cols[i].Name, row[i] = v.Name, v.Value
+	}
+	sheets := []sheet{{Name: "Types", Cols: cols, Rows: [][]any{row}}}
+	data := write(t, cfg, sheets, false)
+	// verify the round-trippable values only
+	want := make([]any, len(row))
+	for i, v := range row {
+		switch x := v.(type) {
+		case string, int, nil:
+			want[i] = x
+		case sql.NullString:
+			if x.Valid {
+				want[i] = x.String
+			}
+		default:
+			want[i] = any(skip{})
+		}
+	}
+	sheets[0].Rows = [][]any{want}
+	verify(t, cfg, data, sheets)
+}
+
+// skip is a value that is not verified.
+type skip struct{}
+
+func testStyles(t *testing.T, cfg Config) {
+	bold := spreadsheet.Style{FontBold: true}
+	cols := []spreadsheet.Column{
+		{Name: "bold header", Header: bold},
+		{Name: "bold column", Column: bold},
+		{Name: "number", Header: bold, Column: spreadsheet.Style{Format: "#,##0.00"}},
+		{Name: "date", Column: spreadsheet.Style{Format: "yyyy-mm-dd"}},
+		{Name: "bold number", Column: spreadsheet.Style{Format: "0.000", FontBold: true}},
+		{Name: "colored", Column: spreadsheet.Style{FontColor: "#C00000", BackgroundColor: "#FFEB9C"}},
+	}
+	var rows [][]any
+	for i := range 10 {
+		rows = append(rows, []any{strconv.Itoa(i), i, float64(i) * 1000.5,
+			time.Date(2026, 1, i+1, 0, 0, 0, 0, time.Local), float64(i) / 3, "c" + strconv.Itoa(i)})
+	}
+	sheets := []sheet{{Name: "Styles", Cols: cols, Rows: rows}}
+	data := write(t, cfg, sheets, false)
+	for _, row := range rows {
+		row[2], row[3], row[4] = skip{}, skip{}, skip{}
+	}
+	verify(t, cfg, data, sheets)
+
+	if cfg.Style == nil {
+		return
+	}
+	for j, c := range cols {
+		for row, want := range []spreadsheet.Style{c.Header, c.Column} {
+			if row == 0 && want == (spreadsheet.Style{}) {
+				continue // the header may have the column's style
+			}
+			got, err := cfg.Style(data, sheets[0].Name, row, j)
+			if err != nil {
+				t.Fatalf("Style(%d, %d): %+v", row, j, err)
+			}
+			if got.FontBold != want.FontBold ||
+				!strings.EqualFold(got.FontColor, want.FontColor) ||
+				!strings.EqualFold(got.BackgroundColor, want.BackgroundColor) {
+				ref := spreadsheet.CellError{Sheet: sheets[0].Name, Row: row, Col: j,
+					Err: fmt.Errorf("got style %+v, wanted %+v", got, want)}
+				t.Error(ref.Error())
+			}
+		}
+	}
+}
+
+func testEmptySheets(t *testing.T, cfg Config) {
+	sheets := []sheet{
+		{Name: "Empty"},
+		{Name: "Header only", Cols: []spreadsheet.Column{{Name: "a"}, {Name: "b"}}},
+		{Name: "Unnamed columns", Cols: make([]spreadsheet.Column, 3), Rows: [][]any{{1, 2, 3}}},
+		{Name: "Empty rows", Cols: []spreadsheet.Column{{Name: "a"}}, Rows: [][]any{{}, {"x"}, {}}},
+	}
+	if cfg.SingleSheet {
+		for _, s := range sheets {
+			verify(t, cfg, write(t, cfg, []sheet{s}, false), []sheet{s})
+		}
+		return
+	}
+	verify(t, cfg, write(t, cfg, sheets, false), sheets)
+}
+
+func testHostileStrings(t *testing.T, cfg Config) {
+	var rows [][]any
+	for _, s := range HostileStrings {
+		rows = append(rows, []any{s, strings.ToUpper(s)})
+	}
+	name := "Hostile <&> \"sheet\" ű"
+	if cfg.SingleSheet {
+		name = "Hostile"
+	}
+	sheets := []sheet{{
+		Name: name,
+		Cols: []spreadsheet.Column{{Name: "<value>"}, {Name: "\"upper\", 'ÁRVÍZ'"}},
+		Rows: rows,
+	}}
+	verify(t, cfg, write(t, cfg, sheets, false), sheets)
+}
+
+func testTooManyRows(t *testing.T, cfg Config) {
+	if cfg.MaxRows <= 0 {
+		t.Skip("MaxRows is not set")
+	}
+	if cfg.MaxRows > 100_000 && testing.Short() {
+		t.Skipf("%d rows in -short mode", cfg.MaxRows)
+	}
+	w, err := cfg.New(io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	sh, err := w.NewSheet("Full", []spreadsheet.Column{{Name: "n"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the header row counts, too
+	for i := 1; i < cfg.MaxRows; i++ {
+		if err = sh.AppendRow(i); err != nil {
+			t.Fatalf("AppendRow[%d]: %+v", i, err)
+		}
+	}
+	if err = sh.AppendRow(cfg.MaxRows); !errors.Is(err, spreadsheet.ErrTooManyRows) {
+		t.Errorf("got %v after %d rows, wanted %v", err, cfg.MaxRows, spreadsheet.ErrTooManyRows)
+	}
+	if err = sh.Close(); err != nil {
+		t.Error(err)
+	}
+}
+
+// testProtection protects the workbook and a sheet, if the writer is a spreadsheet.Protector:
+// the protected data must be readable.
+func testProtection(t *testing.T, cfg Config) {
+	var buf bytes.Buffer
+	w, err := cfg.New(&buf)
+	if err != nil {
+		t.Fatal("New:", err)
+	}
+	p, ok := w.(spreadsheet.Protector)
+	if !ok {
+		w.Close()
+		t.Skipf("%T is not a Protector", w)
+	}
+	if err = p.ProtectWorkbook("secret"); err != nil {
+		t.Fatal("ProtectWorkbook:", err)
+	}
+	sheets := []sheet{
+		{Name: "Protected", Cols: []spreadsheet.Column{
+			{Name: "input", Column: spreadsheet.Style{Unlocked: true}},
+			{Name: "fixed", Header: spreadsheet.Style{FontBold: true, Unlocked: true}},
+		}},
+		{Name: "Open", Cols: []spreadsheet.Column{{Name: "a"}, {Name: "b"}}},
+		{Name: "No password", Cols: []spreadsheet.Column{{Name: "a"}, {Name: "b"}}},
+	}
+	if err = p.ProtectSheet("Protected", spreadsheet.SheetProtection{
+		Password: "secret", AllowSort: true, AllowFilter: true, AllowInsertRows: true,
+	}); err != nil {
+		t.Fatal("ProtectSheet:", err)
+	}
+	if err = p.ProtectSheet("No password", spreadsheet.SheetProtection{}); err != nil {
+		t.Fatal("ProtectSheet:", err)
+	}
+	for i := range sheets {
+		s := &sheets[i]
+		s.Rows = [][]any{{"x", 1}, {nil, 2}}
+		sh, err := w.NewSheet(s.Name, s.Cols)
+		if err != nil {
+			t.Fatalf("NewSheet(%q): %+v", s.Name, err)
+		}
+		for _, row := range s.Rows {
+			if err = sh.AppendRow(row...); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err = sh.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal("Close:", err)
+	}
+	if cfg.SingleSheet {
+		sheets = sheets[:1]
+	}
+	verify(t, cfg, buf.Bytes(), sheets)
+}
+
+// testValidation writes columns with validations - the values must be readable,
+// without extra rows.
+func testValidation(t *testing.T, cfg Config) {
+	sheets := []sheet{{
+		Name: "Input",
+		Cols: []spreadsheet.Column{
+			{Name: "choice", Validation: &spreadsheet.Validation{
+				Type: spreadsheet.ValidateList, List: []string{"yes", "no", `"maybe"; or not`},
+				ErrorTitle: "Invalid choice", ErrorMessage: "Choose from the list!",
+			}},
+			{Name: "count", Validation: &spreadsheet.Validation{
+				Type: spreadsheet.ValidateWhole, Min: 1, Max: 10,
+			}},
+			{Name: "amount", Validation: &spreadsheet.Validation{
+				Type: spreadsheet.ValidateDecimal, Min: 0.5,
+			}},
+			{Name: "no validation"},
+			{Name: "date", Validation: &spreadsheet.Validation{
+				Type: spreadsheet.ValidateDate, Max: time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC),
+			}},
+			{Name: "code", Validation: &spreadsheet.Validation{
+				Type: spreadsheet.ValidateTextLength, Min: 2, Max: 8,
+			}},
+			{Name: "from list", Validation: &spreadsheet.Validation{
+				Type: spreadsheet.ValidateList, ListRange: "'Code lists'!$A$2:$A$3",
+			}},
+		},
+		Rows: [][]any{
+			{"yes", 1, skip{}, "x", skip{}, "ab", "a"},
+			{"no", 10, skip{}, "y", skip{}, "abcdefgh", "b"},
+		},
+	}, {
+		Name: "Code lists",
+		Cols: []spreadsheet.Column{{Name: "code"}},
+		Rows: [][]any{{"a"}, {"b"}},
+	}}
+	for i, row := range sheets[0].Rows {
+		row[2], row[4] = 1.5, time.Date(2026, 1, 2+i, 0, 0, 0, 0, time.UTC)
+	}
+	if cfg.SingleSheet {
+		sheets = sheets[:1]
+	}
+	data := write(t, cfg, sheets, false)
+	for _, row := range sheets[0].Rows {
+		row[2], row[4] = skip{}, skip{}
+	}
+	verify(t, cfg, data, sheets)
+
+	w, err := cfg.New(io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	if _, err = w.NewSheet("Invalid", []spreadsheet.Column{{Name: "a", Validation: &spreadsheet.Validation{
+		Type: spreadsheet.ValidateWhole,
+	}}}); err == nil {
+		t.Log("an invalid validation is accepted - ignored?")
+	} else if !errors.Is(err, spreadsheet.ErrInvalidValidation) {
+		t.Errorf("got %+v, wanted ErrInvalidValidation", err)
+	}
+}
+
+// testConditionalFormat adds conditional formats to a sheet with colored columns,
+// if the sheets are ConditionalFormatters.
+func testConditionalFormat(t *testing.T, cfg Config) {
+	var buf bytes.Buffer
+	w, err := cfg.New(&buf)
+	if err != nil {
+		t.Fatal("New:", err)
+	}
+	s := sheet{
+		Name: "Invoices",
+		Cols: []spreadsheet.Column{
+			{Name: "id", Header: spreadsheet.Style{FontBold: true, BackgroundColor: "#DDDDDD"}},
+			{Name: "amount", Column: spreadsheet.Style{FontColor: "#000080"}},
+			{Name: "due"},
+			{Name: "paid"},
+		},
+		Rows: [][]any{{"a", -12, "2020-01-02", 1}, {"b", 34, "2099-12-31", 2}, {"c", 0, "", 3}},
+	}
+	sh, err := w.NewSheet(s.Name, s.Cols)
+	if err != nil {
+		t.Fatal("NewSheet:", err)
+	}
+	cf, ok := sh.(spreadsheet.ConditionalFormatter)
+	if !ok {
+		sh.Close()
+		w.Close()
+		t.Skipf("%T is not a ConditionalFormatter", sh)
+	}
+	for _, elt := range []struct {
+		Ref string
+		spreadsheet.ConditionalFormat
+	}{
+		{"B2:B1000", spreadsheet.ConditionalFormat{
+			Type: spreadsheet.ConditionCellValue, Operator: spreadsheet.OperatorLess, Value: "0",
+			Style: spreadsheet.Style{FontColor: "#C00000"},
+		}},
+		{"B2:B1000", spreadsheet.ConditionalFormat{
+			Type: spreadsheet.ConditionCellValue, Operator: spreadsheet.OperatorBetween, Value: "1", Value2: "100",
+			Style: spreadsheet.Style{FontBold: true},
+		}},
+		{"A2:D1000", spreadsheet.ConditionalFormat{
+			Type: spreadsheet.ConditionFormula, Formula: `AND($C2<>"", DATEVALUE($C2)<TODAY())`,
+			Style: spreadsheet.Style{BackgroundColor: "#FFC7CE"},
+		}},
+		{"D2:D1000", spreadsheet.ConditionalFormat{
+			Type: spreadsheet.ConditionColorScale, MinColor: "#F8696B", MidColor: "#FFEB84", MaxColor: "#63BE7B",
+		}},
+		{"D2:D1000", spreadsheet.ConditionalFormat{Type: spreadsheet.ConditionDataBar, BarColor: "#638EC6"}},
+	} {
+		if err = cf.AddConditionalFormat(elt.Ref, elt.ConditionalFormat); err != nil {
+			t.Fatalf("AddConditionalFormat(%q, %+v): %+v", elt.Ref, elt.ConditionalFormat, err)
+		}
+	}
+	if err = cf.AddConditionalFormat("A2:A10", spreadsheet.ConditionalFormat{
+		Type: spreadsheet.ConditionColorScale, MinColor: "red", MaxColor: "#00FF00",
+	}); !errors.Is(err, spreadsheet.ErrInvalidConditionalFormat) {
+		t.Errorf("got %+v, wanted ErrInvalidConditionalFormat", err)
+	}
+	for _, row := range s.Rows {
+		if err = sh.AppendRow(row...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = sh.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal("Close:", err)
+	}
+	s.Rows[2][2] = skip{}
+	verify(t, cfg, buf.Bytes(), []sheet{s})
+}
+
+// testMergeCells merges the cells of the rows, if the sheets are CellMergers.
+func testMergeCells(t *testing.T, cfg Config) {
+	var buf bytes.Buffer
+	w, err := cfg.New(&buf)
+	if err != nil {
+		t.Fatal("New:", err)
+	}
+	s := sheet{
+		Name: "Merged",
+		Cols: []spreadsheet.Column{{Name: "a"}, {Name: "b"}, {Name: "c"}},
+		Rows: [][]any{{"across", nil, 1}, {"down", "x", 2}, {nil, "y", 3}, {"block"}, {nil, nil, "z"}},
+	}
+	sh, err := w.NewSheet(s.Name, s.Cols)
+	if err != nil {
+		t.Fatal("NewSheet:", err)
+	}
+	m, ok := sh.(spreadsheet.CellMerger)
+	if !ok {
+		sh.Close()
+		w.Close()
+		t.Skipf("%T is not a CellMerger", sh)
+	}
+	for _, ref := range []string{"A2:B2", "A3:A4", "A5:B6"} {
+		if err = m.MergeCells(ref); err != nil {
+			t.Fatalf("MergeCells(%q): %+v", ref, err)
+		}
+	}
+	for _, row := range s.Rows {
+		if err = sh.AppendRow(row...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = sh.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal("Close:", err)
+	}
+	verify(t, cfg, buf.Bytes(), []sheet{s})
+}
@@ -0,0 +1,544 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sqlscript writes the sheets as SQL scripts: a CREATE TABLE
+// and batched INSERT statements (or PostgreSQL COPY blocks) per sheet.
+package sqlscript
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/UNO-SOFT/spreadsheet"
+	"github.com/UNO-SOFT/spreadsheet/internal/format"
+	"github.com/UNO-SOFT/spreadsheet/internal/spool"
+)
+
+var _ = (spreadsheet.Writer)((*SQLWriter)(nil))
+
+// Dialect is the SQL dialect of the script.
+type Dialect uint8
+
+const (
+	// PostgreSQL dialect.
+	PostgreSQL = Dialect(iota)
+	// Oracle dialect - the batches are written as INSERT ALL.
+	Oracle
+	// SQLite dialect.
+	SQLite
+)
+
+func (d Dialect) String() string {
+	switch d {
+	case PostgreSQL:
+		return "PostgreSQL"
+	case Oracle:
+		return "Oracle"
+	case SQLite:
+		return "SQLite"
+	default:
+		return "Dialect(" + strconv.Itoa(int(d)) + ")"
+	}
+}
+
+// DefaultBatchSize is the default number of rows in one INSERT statement.
+const DefaultBatchSize = 100
+
+// Option is an option for the SQL writer.
+type Option func(*options)
+
+type options struct {
+	batchSize   int
+	dialect     Dialect
+	copy        bool
+	createTable bool
+}
+
+// WithBatchSize sets the number of rows in one INSERT statement (default 100).
+func WithBatchSize(n int) Option { return func(o *options) { o.batchSize = n } }
+
+// WithCreateTable sets whether to write the CREATE TABLE statement (default true).
+func WithCreateTable(create bool) Option { return func(o *options) { o.createTable = create } }
+
+// WithCopy sets whether to write COPY ... FROM stdin blocks instead of INSERTs.
+//
+// Only the PostgreSQL dialect supports this, and only psql can execute it.
+func WithCopy(useCopy bool) Option { return func(o *options) { o.copy = useCopy } }
+
+// SQLWriter writes the sheets as SQL script.
+type SQLWriter struct {
+	spool *spool.Spool
+	opts  options
+	mu    sync.Mutex
+}
+
+// NewWriter returns a spreadsheet.Writer that writes an SQL script into w,
+// in the given dialect, the sheets in the order of their creation.
+//
+// The sheet names are used as table names, the column names (or the column letters,
+// when the name is empty) as column names, all quoted.
+//
+// This writer allows concurrent writes to separate sheets.
+func NewWriter(w io.Writer, dialect Dialect, opts ...Option) (*SQLWriter, error) {
+	o := options{dialect: dialect, batchSize: DefaultBatchSize, createTable: true}
+	for _, f := range opts {
+		f(&o)
+	}
+	if o.dialect > SQLite {
+		return nil, fmt.Errorf("unknown dialect %v", o.dialect)
+	}
+	if o.copy && o.dialect != PostgreSQL {
+		return nil, fmt.Errorf("COPY is not supported by %v", o.dialect)
+	}
+	if o.batchSize <= 0 {
+		o.batchSize = 1
+	}
+	return &SQLWriter{spool: spool.New(w), opts: o}, nil
+}
+
+// Close the writer: copies the finished sheets.
+//
+// Does not close the underlying io.Writer.
+func (sw *SQLWriter) Close() error {
+	if sw == nil {
+		return nil
+	}
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	sp := sw.spool
+	sw.spool = nil
+	if sp == nil {
+		return nil
+	}
+	return sp.Close()
+}
+
+// NewSheet returns a new sheet, written as a table.
+//
+// The column types of the CREATE TABLE statement are derived from the
+// first non-null value of each column in the first batch of rows,
+// so the statement is written with the first batch.
+func (sw *SQLWriter) NewSheet(name string, cols []spreadsheet.Column) (spreadsheet.Sheet, error) {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	if sw.spool == nil {
+		return nil, os.ErrClosed
+	}
+	table, err := quote(sw.opts.dialect, name)
+	if err != nil {
+		return nil, err
+	}
+	ss := &SQLSheet{Name: name, opts: &sw.opts, table: table}
+	for i, c := range cols {
+		if err = ss.addColumn(i, c.Name); err != nil {
+			return nil, err
+		}
+	}
+	if ss.part, err = sw.spool.NewPart(nil); err != nil {
+		return nil, err
+	}
+	return ss, nil
+}
+
+// SQLSheet is a table.
+type SQLSheet struct {
+	part    *spool.Part
+	opts    *options
+	Name    string
+	table   string
+	columns []string
+	types   []colType
+	rows    [][]any
+	buf     bytes.Buffer
+	started bool
+	mu      sync.Mutex
+}
+
+func (ss *SQLSheet) addColumn(i int, name string) error {
+	if name == "" {
+		name = spreadsheet.ColumnName(i)
+	}
+	quoted, err := quote(ss.opts.dialect, name)
+	if err != nil {
+		return err
+	}
+	ss.columns = append(ss.columns, quoted)
+	return nil
+}
+
+// AppendRow appends a row - the rows are written in batches.
+func (ss *SQLSheet) AppendRow(values ...any) error {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if ss.part == nil {
+		return os.ErrClosed
+	}
+	if len(values) > len(ss.columns) {
+		if ss.started {
+			return fmt.Errorf("%s: got %d values, the table has only %d columns", ss.Name, len(values), len(ss.columns))
+		}
+		for i := len(ss.columns); i < len(values); i++ {
+			if err := ss.addColumn(i, ""); err != nil {
+				return err
+			}
+		}
+	}
+	row := make([]any, len(values))
+	for i, v := range values {
+		row[i] = format.Value(v)
+	}
+	ss.rows = append(ss.rows, row)
+	if len(ss.rows) >= ss.opts.batchSize {
+		return ss.flush()
+	}
+	return nil
+}
+
+// Close writes the remaining rows.
+func (ss *SQLSheet) Close() error {
+	if ss == nil {
+		return nil
+	}
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if ss.part == nil {
+		return nil
+	}
+	err := ss.flush()
+	if err == nil && ss.opts.copy && ss.started {
+		_, err = io.WriteString(ss.part, "\\.\n\n")
+	}
+	part := ss.part
+	ss.part = nil
+	if closeErr := part.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// flush writes the buffered rows, preceded by the CREATE TABLE
+// (and the COPY) statement on the first call.
+func (ss *SQLSheet) flush() error {
+	ss.buf.Reset()
+	if !ss.started {
+		if len(ss.rows) == 0 && !ss.opts.createTable {
+			return nil
+		}
+		ss.started = true
+		ss.inferTypes()
+		if ss.opts.createTable {
+			ss.writeCreateTable()
+		}
+		if ss.opts.copy {
+			fmt.Fprintf(&ss.buf, "COPY %s (%s) FROM stdin;\n", ss.table, strings.Join(ss.columns, ", "))
+		}
+	}
+	if len(ss.rows) != 0 {
+		if ss.opts.copy {
+			ss.writeCopyRows()
+		} else {
+			ss.writeInsert()
+		}
+	}
+	ss.rows = ss.rows[:0]
+	_, err := ss.part.Write(ss.buf.Bytes())
+	return err
+}
+
+type colType uint8
+
+const (
+	typeText = colType(iota)
+	typeInt
+	typeFloat
+	typeNumber
+	typeBool
+	typeTime
+	typeBytes
+)
+
+var typeNames = [...][typeBytes + 1]string{
+	PostgreSQL: {"TEXT", "BIGINT", "DOUBLE PRECISION", "NUMERIC", "BOOLEAN", "TIMESTAMP", "BYTEA"},
+	Oracle:     {"VARCHAR2(4000)", "NUMBER(19)", "BINARY_DOUBLE", "NUMBER", "NUMBER(1)", "TIMESTAMP", "BLOB"},
+	SQLite:     {"TEXT", "INTEGER", "REAL", "NUMERIC", "INTEGER", "TEXT", "BLOB"},
+}
+
+func typeOf(v any) (colType, bool) {
+	switch x := v.(type) {
+	case nil:
+		return typeText, false
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return typeInt, true
+	case float32, float64:
+		return typeFloat, true
+	case spreadsheet.Number:
+		if x == "" {
+			return typeText, false
+		}
+		if rNumber.MatchString(string(x)) {
+			return typeNumber, true
+		}
+	case bool:
+		return typeBool, true
+	case time.Time:
+		return typeTime, !x.IsZero()
+	case []byte:
+		return typeBytes, true
+	}
+	return typeText, true
+}
+
+// inferTypes sets the column types from the first non-null values of the buffered rows.
+func (ss *SQLSheet) inferTypes() {
+	ss.types = make([]colType, len(ss.columns))
+	for i := range ss.types {
+		for _, row := range ss.rows {
+			if i < len(row) {
+				if t, ok := typeOf(row[i]); ok {
+					ss.types[i] = t
+					break
+				}
+			}
+		}
+	}
+}
+
+func (ss *SQLSheet) writeCreateTable() {
+	names := &typeNames[ss.opts.dialect]
+	fmt.Fprintf(&ss.buf, "CREATE TABLE %s (\n", ss.table)
+	for i, c := range ss.columns {
+		if i != 0 {
+			ss.buf.WriteString(",\n")
+		}
+		fmt.Fprintf(&ss.buf, "  %s %s", c, names[ss.types[i]])
+	}
+	ss.buf.WriteString("\n);\n\n")
+}
+
+func (ss *SQLSheet) writeInsert() {
+	columns := strings.Join(ss.columns, ", ")
+	if ss.opts.dialect == Oracle {
+		ss.buf.WriteString("INSERT ALL\n")
+		for _, row := range ss.rows {
+			fmt.Fprintf(&ss.buf, "  INTO %s (%s) VALUES (", ss.table, columns)
+			ss.writeValues(row)
+			ss.buf.WriteString(")\n")
+		}
+		ss.buf.WriteString("SELECT 1 FROM DUAL;\n\n")
+		return
+	}
+	fmt.Fprintf(&ss.buf, "INSERT INTO %s (%s) VALUES\n", ss.table, columns)
+	for i, row := range ss.rows {
+		if i != 0 {
+			ss.buf.WriteString(",\n")
+		}
+		ss.buf.WriteString("  (")
+		ss.writeValues(row)
+		ss.buf.WriteByte(')')
+	}
+	ss.buf.WriteString(";\n\n")
+}
+
+func (ss *SQLSheet) writeValues(row []any) {
+	for i := range ss.columns {
+		if i != 0 {
+			ss.buf.WriteString(", ")
+		}
+		var v any
+		if i < len(row) {
+			v = row[i]
+		}
+		ss.buf.WriteString(literal(ss.opts.dialect, v))
+	}
+}
+
+// writeCopyRows writes the rows in the text format of COPY: tab separated,
+// with backslash escapes and \N as NULL.
+func (ss *SQLSheet) writeCopyRows() {
+	for _, row := range ss.rows {
+		for i := range ss.columns {
+			if i != 0 {
+				ss.buf.WriteByte('\t')
+			}
+			var v any
+			if i < len(row) {
+				v = row[i]
+			}
+			ss.buf.WriteString(copyText(v))
+		}
+		ss.buf.WriteByte('\n')
+	}
+}
+
+var rNumber = regexp.MustCompile(`^[-+]?([0-9]+(\.[0-9]*)?|\.[0-9]+)([eE][-+]?[0-9]+)?$`)
+
+// quote the identifier - Oracle does not allow double quotes in quoted identifiers.
+func quote(dialect Dialect, name string) (string, error) {
+	if dialect == Oracle && strings.Contains(name, `"`) {
+		return "", fmt.Errorf("%q: Oracle identifiers cannot contain double quotes", name)
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`, nil
+}
+
+// quoteString returns the string literal.
+func quoteString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// literal returns the SQL literal of the value.
+func literal(dialect Dialect, v any) string {
+	switch x := v.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		return quoteString(x)
+	case spreadsheet.Number:
+		if x == "" {
+			return "NULL"
+		}
+		if rNumber.MatchString(string(x)) {
+			return string(x)
+		}
+		return quoteString(string(x))
+	case bool:
+		if dialect == PostgreSQL {
+			if x {
+				return "TRUE"
+			}
+			return "FALSE"
+		}
+		if x {
+			return "1"
+		}
+		return "0"
+	case float64:
+		return floatLiteral(dialect, x, 64)
+	case float32:
+		return floatLiteral(dialect, float64(x), 32)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", x)
+	case time.Time:
+		if x.IsZero() {
+			return "NULL"
+		}
+		if dialect == SQLite {
+			return quoteString(timeText(x))
+		}
+		// the TIMESTAMP literal needs the time, too
+		return "TIMESTAMP " + quoteString(x.Format("2006-01-02 15:04:05.999999999"))
+	case []byte:
+		switch dialect {
+		case PostgreSQL:
+			return `'\x` + hex.EncodeToString(x) + "'"
+		case Oracle:
+			if len(x) == 0 {
+				return "EMPTY_BLOB()"
+			}
+			return "HEXTORAW('" + hex.EncodeToString(x) + "')"
+		default:
+			return "X'" + hex.EncodeToString(x) + "'"
+		}
+	default:
+		s, _ := format.Text(x, "")
+		return quoteString(s)
+	}
+}
+
+func floatLiteral(dialect Dialect, f float64, bitSize int) string {
+	if !math.IsNaN(f) && !math.IsInf(f, 0) {
+		return strconv.FormatFloat(f, 'g', -1, bitSize)
+	}
+	switch dialect {
+	case PostgreSQL:
+		switch {
+		case math.IsNaN(f):
+			return "'NaN'"
+		case f > 0:
+			return "'Infinity'"
+		default:
+			return "'-Infinity'"
+		}
+	case Oracle:
+		switch {
+		case math.IsNaN(f):
+			return "BINARY_DOUBLE_NAN"
+		case f > 0:
+			return "BINARY_DOUBLE_INFINITY"
+		default:
+			return "-BINARY_DOUBLE_INFINITY"
+		}
+	default:
+		switch {
+		case math.IsNaN(f):
+			return "NULL"
+		case f > 0:
+			return "9e999"
+		default:
+			return "-9e999"
+		}
+	}
+}
+
+// timeText returns the time in "YYYY-MM-DD HH:MM:SS[.fraction]" format, without time zone.
+func timeText(t time.Time) string {
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format("2006-01-02 15:04:05.999999999")
+}
+
+var copyEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// copyText returns the value in the text format of COPY.
+func copyText(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return `\N`
+	case spreadsheet.Number:
+		if x == "" {
+			return `\N`
+		}
+		return copyEscaper.Replace(string(x))
+	case bool:
+		if x {
+			return "t"
+		}
+		return "f"
+	case float64:
+		return copyFloat(x, 64)
+	case float32:
+		return copyFloat(float64(x), 32)
+	case time.Time:
+		if x.IsZero() {
+			return `\N`
+		}
+		return timeText(x)
+	case []byte:
+		return `\\x` + hex.EncodeToString(x)
+	default:
+		s, _ := format.Text(x, "")
+		return copyEscaper.Replace(s)
+	}
+}
+
+func copyFloat(f float64, bitSize int) string {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	}
+	return strconv.FormatFloat(f, 'g', -1, bitSize)
+}
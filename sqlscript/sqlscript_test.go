@@ -0,0 +1,182 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlscript_test
+
+import (
+	"bytes"
+	"database/sql"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/UNO-SOFT/spreadsheet"
+	"github.com/UNO-SOFT/spreadsheet/spreadsheettest"
+	"github.com/UNO-SOFT/spreadsheet/sqlscript"
+)
+
+func TestConformance(t *testing.T) {
+	spreadsheettest.Run(t, spreadsheettest.Config{
+		New: func(w io.Writer) (spreadsheet.Writer, error) { return sqlscript.NewWriter(w, sqlscript.PostgreSQL) },
+	})
+}
+
+func TestOracle(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := sqlscript.NewWriter(&buf, sqlscript.Oracle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = w.NewSheet(`bad"name`, nil); err == nil {
+		t.Error("no error for a double quote in the table name")
+	}
+	if _, err = w.NewSheet("times", []spreadsheet.Column{{Name: `a"b`}}); err == nil {
+		t.Error("no error for a double quote in the column name")
+	}
+	sheet, err := w.NewSheet("times", []spreadsheet.Column{{Name: "day"}, {Name: "at"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = sheet.AppendRow(
+		time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 10, 19, 12, 34, 56, 500_000_000, time.UTC),
+	); err != nil {
+		t.Fatal(err)
+	}
+	if err = sheet.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	want := `VALUES (TIMESTAMP '2026-10-19 00:00:00', TIMESTAMP '2026-10-19 12:34:56.5')`
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("no %s in %s", want, buf.String())
+	}
+}
+
+var (
+	testCols = []spreadsheet.Column{
+		{Name: "id"}, {Name: "name"}, {Name: "amount"}, {Name: "ok"},
+		{Name: "at"}, {Name: "data"}, {Name: "ratio"}, {Name: "count"},
+	}
+	testRows = [][]any{
+		{1, "O'Brien", spreadsheet.Number("12.50"), true, time.Date(2026, 10, 19, 12, 34, 56, 0, time.UTC), []byte{0xde, 0xad}, 0.5, sql.NullInt64{}},
+		{2, `back\slash`, nil, false, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), []byte{}, math.Inf(1), sql.NullInt64{Int64: 5, Valid: true}},
+		{3, nil, spreadsheet.Number(""), nil, time.Time{}, nil, math.Inf(-1), 7},
+	}
+)
+
+// writeScript writes the rows into the "items" table.
+func writeScript(t *testing.T, dialect sqlscript.Dialect, rows [][]any, opts ...sqlscript.Option) string {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := sqlscript.NewWriter(&buf, dialect, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sheet, err := w.NewSheet("items", testCols)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range rows {
+		if err = sheet.AppendRow(row...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = sheet.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestDialects(t *testing.T) {
+	const columns = `("id", "name", "amount", "ok", "at", "data", "ratio", "count")`
+	for _, tc := range []struct {
+		Want    string
+		Opts    []sqlscript.Option
+		Dialect sqlscript.Dialect
+	}{
+		{Dialect: sqlscript.PostgreSQL, Opts: []sqlscript.Option{sqlscript.WithBatchSize(2)}, Want: `CREATE TABLE "items" (
+  "id" BIGINT,
+  "name" TEXT,
+  "amount" NUMERIC,
+  "ok" BOOLEAN,
+  "at" TIMESTAMP,
+  "data" BYTEA,
+  "ratio" DOUBLE PRECISION,
+  "count" BIGINT
+);
+
+INSERT INTO "items" ` + columns + ` VALUES
+  (1, 'O''Brien', 12.50, TRUE, TIMESTAMP '2026-10-19 12:34:56', '\xdead', 0.5, NULL),
+  (2, 'back\slash', NULL, FALSE, TIMESTAMP '2026-10-19 00:00:00', '\x', 'Infinity', 5);
+
+INSERT INTO "items" ` + columns + ` VALUES
+  (3, NULL, NULL, NULL, NULL, NULL, '-Infinity', 7);
+
+`},
+		{Dialect: sqlscript.Oracle, Want: `CREATE TABLE "items" (
+  "id" NUMBER(19),
+  "name" VARCHAR2(4000),
+  "amount" NUMBER,
+  "ok" NUMBER(1),
+  "at" TIMESTAMP,
+  "data" BLOB,
+  "ratio" BINARY_DOUBLE,
+  "count" NUMBER(19)
+);
+
+INSERT ALL
+  INTO "items" ` + columns + ` VALUES (1, 'O''Brien', 12.50, 1, TIMESTAMP '2026-10-19 12:34:56', HEXTORAW('dead'), 0.5, NULL)
+  INTO "items" ` + columns + ` VALUES (2, 'back\slash', NULL, 0, TIMESTAMP '2026-10-19 00:00:00', EMPTY_BLOB(), BINARY_DOUBLE_INFINITY, 5)
+  INTO "items" ` + columns + ` VALUES (3, NULL, NULL, NULL, NULL, NULL, -BINARY_DOUBLE_INFINITY, 7)
+SELECT 1 FROM DUAL;
+
+`},
+		{Dialect: sqlscript.SQLite, Want: `CREATE TABLE "items" (
+  "id" INTEGER,
+  "name" TEXT,
+  "amount" NUMERIC,
+  "ok" INTEGER,
+  "at" TEXT,
+  "data" BLOB,
+  "ratio" REAL,
+  "count" INTEGER
+);
+
+INSERT INTO "items" ` + columns + ` VALUES
+  (1, 'O''Brien', 12.50, 1, '2026-10-19 12:34:56', X'dead', 0.5, NULL),
+  (2, 'back\slash', NULL, 0, '2026-10-19', X'', 9e999, 5),
+  (3, NULL, NULL, NULL, NULL, NULL, -9e999, 7);
+
+`},
+	} {
+		t.Run(tc.Dialect.String(), func(t *testing.T) {
+			if got := writeScript(t, tc.Dialect, testRows, tc.Opts...); got != tc.Want {
+				t.Errorf("got\n%s\nwanted\n%s", got, tc.Want)
+			}
+		})
+	}
+}
+
+func TestCopy(t *testing.T) {
+	rows := append([][]any{{0, "tab\tline\nback\\slash\r"}}, testRows...)
+	got := writeScript(t, sqlscript.PostgreSQL, rows, sqlscript.WithCopy(true), sqlscript.WithCreateTable(false))
+	want := `COPY "items" ("id", "name", "amount", "ok", "at", "data", "ratio", "count") FROM stdin;
+` +
+		"0\ttab\\tline\\nback\\\\slash\\r\t\\N\t\\N\t\\N\t\\N\t\\N\t\\N\n" +
+		"1\tO'Brien\t12.50\tt\t2026-10-19 12:34:56\t\\\\xdead\t0.5\t\\N\n" +
+		"2\tback\\\\slash\t\\N\tf\t2026-10-19\t\\\\x\tInfinity\t5\n" +
+		"3\t\\N\t\\N\t\\N\t\\N\t\\N\t-Infinity\t7\n" +
+		"\\.\n\n"
+	if got != want {
+		t.Errorf("got\n%q\nwanted\n%q", got, want)
+	}
+}
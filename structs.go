@@ -0,0 +1,179 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package spreadsheet
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ColumnsOf returns the columns for the struct type T (or *T).
+//
+// The exported fields are the columns, in declaration order,
+// with the fields of the embedded structs flattened.
+// The field's "spreadsheet" tag may set the column name,
+// the number format, bold font and unlocked (editable in a protected sheet) cells:
+//
+//	Amount float64 `spreadsheet:"amount,format=#,##0.00,bold,unlocked"`
+//
+// The format value lasts till the next known option (bold, unlocked),
+// so it may contain commas. A "-" tag skips the field.
+//
+// The returned slice is a copy, so it may be modified.
+// Returns nil if T is not a struct (or a pointer to a struct).
+func ColumnsOf[T any]() []Column {
+	p, err := planOf(reflect.TypeFor[T]())
+	if err != nil {
+		return nil
+	}
+	return slices.Clone(p.cols)
+}
+
+// ErrNotStruct is returned for a type which is not a struct (or a pointer to a struct).
+var ErrNotStruct = errors.New("not a struct")
+
+// WriteStructs appends the structs as rows to the sheet, with the fields
+// in the order of ColumnsOf[T].
+//
+// The per-type field plans are cached. Returns ErrNotStruct if T is not a struct
+// (or a pointer to a struct).
+func WriteStructs[T any](sheet Sheet, seq iter.Seq[T]) error {
+	p, err := planOf(reflect.TypeFor[T]())
+	if err != nil {
+		return err
+	}
+	values := make([]any, len(p.fields))
+	var n int
+	for s := range seq {
+		n++
+		p.values(values, reflect.ValueOf(&s).Elem())
+		if err := sheet.AppendRow(values...); err != nil {
+			return fmt.Errorf("row %d: %w", n, err)
+		}
+	}
+	return nil
+}
+
+// WriteStructSlice appends the structs as rows to the sheet, see WriteStructs.
+func WriteStructSlice[T any](sheet Sheet, structs []T) error {
+	return WriteStructs(sheet, func(yield func(T) bool) {
+		for _, s := range structs {
+			if !yield(s) {
+				return
+			}
+		}
+	})
+}
+
+type structPlan struct {
+	cols   []Column
+	fields [][]int
+	ptr    bool
+}
+
+var structPlans sync.Map // reflect.Type -> *structPlan
+
+func planOf(t reflect.Type) (*structPlan, error) {
+	if p, ok := structPlans.Load(t); ok {
+		return p.(*structPlan), nil
+	}
+	var p structPlan
+	st := t
+	if st.Kind() == reflect.Pointer {
+		st, p.ptr = st.Elem(), true
+	}
+	if st.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%v: %w", t, ErrNotStruct)
+	}
+	p.addFields(st, nil)
+	v, _ := structPlans.LoadOrStore(t, &p)
+	return v.(*structPlan), nil
+}
+
+func (p *structPlan) addFields(t reflect.Type, index []int) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag, hasTag := f.Tag.Lookup("spreadsheet")
+		if tag == "-" {
+			continue
+		}
+		idx := append(append(make([]int, 0, len(index)+1), index...), i)
+		if f.Anonymous && !hasTag {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				p.addFields(ft, idx)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		col := Column{Name: f.Name}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name != "" {
+			col.Name = name
+		}
+		for opts != "" {
+			var opt string
+			opt, opts, _ = strings.Cut(opts, ",")
+			switch {
+			case opt == "bold":
+				col.Column.FontBold = true
+			case opt == "unlocked":
+				col.Column.Unlocked = true
+			case strings.HasPrefix(opt, "format="):
+				col.Column.Format = strings.TrimPrefix(opt, "format=")
+				// the format may contain commas: consume till the next known option
+				for opts != "" {
+					next, rest, _ := strings.Cut(opts, ",")
+					if next == "bold" || next == "unlocked" || strings.HasPrefix(next, "format=") {
+						break
+					}
+					col.Column.Format += "," + next
+					opts = rest
+				}
+			}
+		}
+		p.cols = append(p.cols, col)
+		p.fields = append(p.fields, idx)
+	}
+}
+
+var errNilPointer = errors.New("nil pointer")
+
+// values fills the values from the fields of v - pointer fields are dereferenced,
+// nil for a nil pointer or a field of a nil embedded pointer.
+func (p *structPlan) values(values []any, v reflect.Value) {
+	if p.ptr {
+		if v.IsNil() {
+			clear(values)
+			return
+		}
+		v = v.Elem()
+	}
+	for i, idx := range p.fields {
+		f, err := v.FieldByIndexErr(idx)
+		if err == nil && f.Kind() == reflect.Pointer {
+			if f.IsNil() {
+				err = errNilPointer
+			} else {
+				f = f.Elem()
+			}
+		}
+		if err != nil {
+			values[i] = nil
+			continue
+		}
+		values[i] = f.Interface()
+	}
+}
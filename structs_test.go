@@ -0,0 +1,85 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package spreadsheet_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/UNO-SOFT/spreadsheet"
+)
+
+type Audit struct {
+	By string `spreadsheet:"by"`
+}
+
+type item struct {
+	*Audit
+	Name    string  `spreadsheet:"name,bold"`
+	Amount  float64 `spreadsheet:"amount,format=#,##0.00,unlocked"`
+	Count   *int
+	Ignored string `spreadsheet:"-"`
+	hidden  string
+}
+
+func TestColumnsOf(t *testing.T) {
+	want := []spreadsheet.Column{
+		{Name: "by"},
+		{Name: "name", Column: spreadsheet.Style{FontBold: true}},
+		{Name: "amount", Column: spreadsheet.Style{Format: "#,##0.00", Unlocked: true}},
+		{Name: "Count"},
+	}
+	if got := spreadsheet.ColumnsOf[item](); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, wanted %+v", got, want)
+	}
+	if got := spreadsheet.ColumnsOf[*item](); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v for the pointer, wanted %+v", got, want)
+	}
+	if got := spreadsheet.ColumnsOf[int](); got != nil {
+		t.Errorf("got %+v for int, wanted nil", got)
+	}
+
+	// the result is the caller's
+	cols := spreadsheet.ColumnsOf[item]()
+	cols[0].Validation = &spreadsheet.Validation{Type: spreadsheet.ValidateList, List: []string{"me"}}
+	cols[1].Header = spreadsheet.Style{BackgroundColor: "#FFFF00"}
+	if got := spreadsheet.ColumnsOf[item](); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v after modifying the result, wanted %+v", got, want)
+	}
+}
+
+func TestWriteStructs(t *testing.T) {
+	var w memWriter
+	sheet, err := w.NewSheet("Items", spreadsheet.ColumnsOf[*item]())
+	if err != nil {
+		t.Fatal(err)
+	}
+	three := 3
+	if err = spreadsheet.WriteStructSlice(sheet, []*item{
+		{Audit: &Audit{By: "me"}, Name: "a", Amount: 1.5, Count: &three, Ignored: "x", hidden: "y"},
+		{Name: "b"},
+		nil,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	want := [][]any{
+		{"me", "a", 1.5, 3},
+		{nil, "b", 0.0, nil},
+		{nil, nil, nil, nil},
+	}
+	if got := w.sheets["Items"].Rows; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %#v, wanted %#v", got, want)
+	}
+
+	if err = spreadsheet.WriteStructSlice(sheet, []int{1}); !errors.Is(err, spreadsheet.ErrNotStruct) {
+		t.Errorf("got %v, wanted %v", err, spreadsheet.ErrNotStruct)
+	}
+
+	w.err = errors.New("full")
+	if err = spreadsheet.WriteStructSlice(sheet, []item{{}}); !errors.Is(err, w.err) {
+		t.Errorf("got %v, wanted %v", err, w.err)
+	}
+}
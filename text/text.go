@@ -0,0 +1,322 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package text
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/mattn/go-runewidth"
+
+	"github.com/UNO-SOFT/spreadsheet"
+	"github.com/UNO-SOFT/spreadsheet/internal/format"
+	"github.com/UNO-SOFT/spreadsheet/internal/spool"
+)
+
+var _ = (spreadsheet.Writer)((*TextWriter)(nil))
+
+func init() {
+	spreadsheet.Register(spreadsheet.Format{
+		Name: "text", Extensions: []string{".txt"}, MIMETypes: []string{"text/plain"},
+		New: func(w io.Writer, _ spreadsheet.Options) (spreadsheet.Writer, error) {
+			return NewWriter(w, Box), nil
+		},
+	})
+	spreadsheet.Register(spreadsheet.Format{
+		Name: "markdown", Extensions: []string{".md", ".markdown"}, MIMETypes: []string{"text/markdown"},
+		New: func(w io.Writer, _ spreadsheet.Options) (spreadsheet.Writer, error) {
+			return NewWriter(w, Markdown), nil
+		},
+	})
+}
+
+// Style is the style of the rendered tables.
+type Style uint8
+
+const (
+	// Box draws the tables with box-drawing characters.
+	Box = Style(iota)
+	// Markdown renders GitHub-flavoured Markdown tables.
+	Markdown
+)
+
+// TextWriter writes the sheets as aligned text tables, each preceded by
+// the sheet name as heading.
+type TextWriter struct {
+	spool  *spool.Spool
+	sheets int
+	style  Style
+	mu     sync.Mutex
+}
+
+// NewWriter returns a spreadsheet.Writer that writes text tables into w.
+//
+// The column widths are computed from the display width of the values,
+// (East Asian wide characters count as two), so each sheet is kept in memory
+// till it is closed.
+//
+// Numeric columns (where all the values are numbers) are right-aligned.
+//
+// This writer allows concurrent writes to separate sheets.
+func NewWriter(w io.Writer, style Style) *TextWriter {
+	return &TextWriter{spool: spool.New(w), style: style}
+}
+
+// Close the TextWriter: copies the finished sheets.
+//
+// Does not close the underlying io.Writer.
+func (tw *TextWriter) Close() error {
+	if tw == nil {
+		return nil
+	}
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	sp := tw.spool
+	tw.spool = nil
+	if sp == nil {
+		return nil
+	}
+	return sp.Close()
+}
+
+// NewSheet returns a new sheet - the table is rendered when it is closed.
+func (tw *TextWriter) NewSheet(name string, cols []spreadsheet.Column) (spreadsheet.Sheet, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.spool == nil {
+		return nil, os.ErrClosed
+	}
+	part, err := tw.spool.NewPart(nil)
+	if err != nil {
+		return nil, err
+	}
+	ts := &TextSheet{Name: name, part: part, style: tw.style, first: tw.sheets == 0, cols: cols}
+	tw.sheets++
+	return ts, nil
+}
+
+type cell struct {
+	Text  string
+	Width int
+}
+
+func (ts *TextSheet) newCell(s string) cell {
+	if ts.style == Markdown && strings.IndexByte(s, '|') >= 0 {
+		s = escapeMarkdown(s)
+	}
+	if strings.ContainsAny(s, "\r\n\t") {
+		s = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ", "\t", " ").Replace(s)
+	}
+	return cell{Text: s, Width: runewidth.StringWidth(s)}
+}
+
+// TextSheet is a table.
+type TextSheet struct {
+	part  *spool.Part
+	Name  string
+	cols  []spreadsheet.Column
+	rows  [][]cell
+	kinds []format.Kind
+	style Style
+	first bool
+	mu    sync.Mutex
+}
+
+// AppendRow appends a row to the table, the values formatted according to the column's style.
+func (ts *TextSheet) AppendRow(values ...any) error {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.part == nil {
+		return os.ErrClosed
+	}
+	row := make([]cell, len(values))
+	for i, v := range values {
+		var style spreadsheet.Style
+		if i < len(ts.cols) {
+			style = ts.cols[i].Column
+		}
+		text, kind := format.Text(v, style.Format)
+		row[i] = ts.newCell(text)
+		if i >= len(ts.kinds) {
+			ts.kinds = append(ts.kinds, make([]format.Kind, i+1-len(ts.kinds))...)
+		}
+		// A column is numeric iff all its non-null values are numbers.
+		switch {
+		case kind == format.Null:
+		case ts.kinds[i] == format.Null:
+			ts.kinds[i] = kind
+		case ts.kinds[i] != kind:
+			ts.kinds[i] = format.String
+		}
+	}
+	ts.rows = append(ts.rows, row)
+	return nil
+}
+
+// Close renders the table.
+func (ts *TextSheet) Close() error {
+	if ts == nil {
+		return nil
+	}
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	part := ts.part
+	ts.part = nil
+	if part == nil {
+		return nil
+	}
+	bw := bufio.NewWriter(part)
+	ts.render(bw)
+	if err := bw.Flush(); err != nil {
+		part.Close()
+		return err
+	}
+	return part.Close()
+}
+
+func (ts *TextSheet) render(w *bufio.Writer) {
+	var header []cell
+	for _, c := range ts.cols {
+		if c.Name != "" {
+			header = make([]cell, len(ts.cols))
+			for i, c := range ts.cols {
+				header[i] = ts.newCell(c.Name)
+			}
+			break
+		}
+	}
+	if header == nil && ts.style == Markdown {
+		header = make([]cell, len(ts.cols))
+	}
+	n := len(header)
+	for _, row := range ts.rows {
+		n = max(n, len(row))
+	}
+	widths := make([]int, n)
+	if ts.style == Markdown {
+		for i := range widths {
+			widths[i] = 3 // ---
+		}
+	}
+	for _, row := range append([][]cell{header}, ts.rows...) {
+		for i, c := range row {
+			widths[i] = max(widths[i], c.Width)
+		}
+	}
+	rightAlign := make([]bool, n)
+	for i, k := range ts.kinds {
+		rightAlign[i] = k == format.Number
+	}
+
+	if !ts.first {
+		w.WriteByte('\n')
+	}
+	switch ts.style {
+	case Markdown:
+		if ts.Name != "" {
+			w.WriteString("## ")
+			w.WriteString(escapeMarkdown(ts.Name))
+			w.WriteByte('\n')
+		}
+		if n == 0 { // a table needs at least one column
+			return
+		}
+		if ts.Name != "" {
+			w.WriteByte('\n')
+		}
+		markdownRow(w, header, widths, rightAlign)
+		w.WriteByte('|')
+		for i, width := range widths {
+			w.WriteByte(' ')
+			if rightAlign[i] {
+				w.WriteString(strings.Repeat("-", width-1))
+				w.WriteByte(':')
+			} else {
+				w.WriteString(strings.Repeat("-", width))
+			}
+			w.WriteString(" |")
+		}
+		w.WriteByte('\n')
+		for _, row := range ts.rows {
+			markdownRow(w, row, widths, rightAlign)
+		}
+
+	default:
+		if ts.Name != "" {
+			w.WriteString(ts.Name)
+			w.WriteByte('\n')
+		}
+		if n == 0 {
+			return
+		}
+		boxLine(w, widths, "┌", "┬", "┐")
+		if header != nil {
+			boxRow(w, header, widths, nil)
+			boxLine(w, widths, "├", "┼", "┤")
+		}
+		for _, row := range ts.rows {
+			boxRow(w, row, widths, rightAlign)
+		}
+		boxLine(w, widths, "└", "┴", "┘")
+	}
+}
+
+func escapeMarkdown(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+func markdownRow(w *bufio.Writer, row []cell, widths []int, rightAlign []bool) {
+	w.WriteByte('|')
+	for i, width := range widths {
+		var c cell
+		if i < len(row) {
+			c = row[i]
+		}
+		w.WriteByte(' ')
+		pad(w, c, width, rightAlign[i])
+		w.WriteString(" |")
+	}
+	w.WriteByte('\n')
+}
+
+func boxLine(w *bufio.Writer, widths []int, left, middle, right string) {
+	w.WriteString(left)
+	for i, width := range widths {
+		if i != 0 {
+			w.WriteString(middle)
+		}
+		w.WriteString(strings.Repeat("─", width+2))
+	}
+	w.WriteString(right)
+	w.WriteByte('\n')
+}
+
+func boxRow(w *bufio.Writer, row []cell, widths []int, rightAlign []bool) {
+	w.WriteString("│")
+	for i, width := range widths {
+		var c cell
+		if i < len(row) {
+			c = row[i]
+		}
+		w.WriteByte(' ')
+		pad(w, c, width, rightAlign != nil && rightAlign[i])
+		w.WriteString(" │")
+	}
+	w.WriteByte('\n')
+}
+
+func pad(w *bufio.Writer, c cell, width int, right bool) {
+	spaces := strings.Repeat(" ", max(0, width-c.Width))
+	if right {
+		w.WriteString(spaces)
+		w.WriteString(c.Text)
+	} else {
+		w.WriteString(c.Text)
+		w.WriteString(spaces)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package text_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/UNO-SOFT/spreadsheet"
+	"github.com/UNO-SOFT/spreadsheet/spreadsheettest"
+	"github.com/UNO-SOFT/spreadsheet/text"
+)
+
+func TestConformance(t *testing.T) {
+	spreadsheettest.Run(t, spreadsheettest.Config{
+		New: func(w io.Writer) (spreadsheet.Writer, error) { return text.NewWriter(w, text.Box), nil },
+	})
+}
+
+func TestRender(t *testing.T) {
+	for _, tc := range []struct {
+		Name  string
+		Want  string
+		Style text.Style
+	}{
+		{Name: "box", Style: text.Box, Want: `Prices
+┌──────┬────────┐
+│ name │ amount │
+├──────┼────────┤
+│ a|b  │    1.5 │
+│ 漢字 │     12 │
+│      │        │
+└──────┴────────┘
+
+no | names
+┌───┬───┐
+│ x │ 1 │
+└───┴───┘
+
+Empty
+`},
+		{Name: "markdown", Style: text.Markdown, Want: `## Prices
+
+| name | amount |
+| ---- | -----: |
+| a\|b |    1.5 |
+| 漢字 |     12 |
+|      |        |
+
+## no \| names
+
+|     |     |
+| --- | --- |
+| x   | 1   |
+
+## Empty
+`},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := text.NewWriter(&buf, tc.Style)
+			for _, s := range []struct {
+				Name string
+				Cols []spreadsheet.Column
+				Rows [][]any
+			}{
+				{Name: "Prices", Cols: []spreadsheet.Column{{Name: "name"}, {Name: "amount"}},
+					Rows: [][]any{{"a|b", 1.5}, {"漢字", 12}, {nil, nil}}},
+				{Name: "no | names", Cols: []spreadsheet.Column{{}, {}}, Rows: [][]any{{"x", "1"}}},
+				{Name: "Empty"},
+			} {
+				sheet, err := w.NewSheet(s.Name, s.Cols)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, row := range s.Rows {
+					if err = sheet.AppendRow(row...); err != nil {
+						t.Fatal(err)
+					}
+				}
+				if err = sheet.Close(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tc.Want {
+				t.Errorf("got\n%s\nwanted\n%s", got, tc.Want)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package spreadsheet
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// ValidationType is the type of a data validation rule.
+type ValidationType uint8
+
+const (
+	// ValidateList allows the values of List, or of ListRange.
+	ValidateList = ValidationType(iota + 1)
+	// ValidateWhole allows the integers between Min and Max.
+	ValidateWhole
+	// ValidateDecimal allows the numbers between Min and Max.
+	ValidateDecimal
+	// ValidateDate allows the dates between Min and Max.
+	ValidateDate
+	// ValidateTextLength allows the texts with length between Min and Max.
+	ValidateTextLength
+)
+
+func (t ValidationType) String() string {
+	switch t {
+	case ValidateList:
+		return "list"
+	case ValidateWhole:
+		return "whole"
+	case ValidateDecimal:
+		return "decimal"
+	case ValidateDate:
+		return "date"
+	case ValidateTextLength:
+		return "textLength"
+	default:
+		return "ValidationType(" + strconv.Itoa(int(t)) + ")"
+	}
+}
+
+// Validation is a data validation rule for the cells of a column, below the header.
+// The empty cells are always valid.
+type Validation struct {
+	// List is the allowed values of ValidateList.
+	List []string
+	// ListRange is the range of the allowed values of ValidateList, used when List is empty,
+	// such as "Lists!$A$1:$A$10" or "'Code lists'!A1:A20".
+	ListRange string
+	// Min and Max are the inclusive bounds: an integer or a float,
+	// or a time.Time for ValidateDate. Nil is unbounded.
+	Min, Max any
+	// ErrorTitle and ErrorMessage are shown when an invalid value is entered.
+	ErrorTitle, ErrorMessage string
+	// Type is the kind of the rule.
+	Type ValidationType
+}
+
+// ErrInvalidValidation is returned for an incomplete or inconsistent Validation.
+var ErrInvalidValidation = errors.New("invalid validation")
+
+// Bounds checks the validation, and returns the Min and Max bounds formatted as numbers,
+// the dates as date serial numbers (days since 1899-12-30).
+// An empty string is unbounded.
+func (v Validation) Bounds() (lo, hi string, err error) {
+	switch v.Type {
+	case ValidateList:
+		if len(v.List) == 0 && v.ListRange == "" {
+			return "", "", fmt.Errorf("%w: no List or ListRange", ErrInvalidValidation)
+		}
+		return "", "", nil
+	case ValidateWhole, ValidateDecimal, ValidateDate, ValidateTextLength:
+	default:
+		return "", "", fmt.Errorf("%w: unknown type %v", ErrInvalidValidation, v.Type)
+	}
+	if v.Min == nil && v.Max == nil {
+		return "", "", fmt.Errorf("%w: %v without Min and Max", ErrInvalidValidation, v.Type)
+	}
+	if lo, err = v.bound(v.Min); err != nil {
+		return "", "", err
+	}
+	hi, err = v.bound(v.Max)
+	return lo, hi, err
+}
+
+func (v Validation) bound(b any) (string, error) {
+	if b == nil {
+		return "", nil
+	}
+	if t, ok := b.(time.Time); ok {
+		if v.Type != ValidateDate {
+			return "", fmt.Errorf("%w: time bound for %v", ErrInvalidValidation, v.Type)
+		}
+		// time.Duration overflows after 292 years, so count the days from the Unix time
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return strconv.FormatInt((day.Unix()-dateEpoch.Unix())/86400, 10), nil
+	}
+	rv := reflect.ValueOf(b)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		if f := rv.Float(); !math.IsNaN(f) && !math.IsInf(f, 0) {
+			return strconv.FormatFloat(f, 'g', -1, 64), nil
+		}
+	}
+	return "", fmt.Errorf("%w: bound %v (%T)", ErrInvalidValidation, b, b)
+}
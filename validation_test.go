@@ -0,0 +1,46 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package spreadsheet_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/UNO-SOFT/spreadsheet"
+)
+
+func TestValidationBounds(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 13, 14, 15, 0, time.FixedZone("", 3600))
+	}
+	for _, tc := range []struct {
+		Validation     spreadsheet.Validation
+		WantLo, WantHi string
+		WantErr        error
+	}{
+		{Validation: spreadsheet.Validation{Type: spreadsheet.ValidateWhole, Min: 1, Max: uint8(10)}, WantLo: "1", WantHi: "10"},
+		{Validation: spreadsheet.Validation{Type: spreadsheet.ValidateDecimal, Max: 0.5}, WantHi: "0.5"},
+		{Validation: spreadsheet.Validation{Type: spreadsheet.ValidateDate, Min: date(1900, 1, 1), Max: date(9999, 12, 31)}, WantLo: "2", WantHi: "2958465"},
+		{Validation: spreadsheet.Validation{Type: spreadsheet.ValidateDate, Min: date(1000, 1, 1)}, WantLo: "-328716"},
+		{Validation: spreadsheet.Validation{Type: spreadsheet.ValidateWhole, Min: date(2000, 1, 1)}, WantErr: spreadsheet.ErrInvalidValidation},
+		{Validation: spreadsheet.Validation{Type: spreadsheet.ValidateDecimal, Min: "1"}, WantErr: spreadsheet.ErrInvalidValidation},
+		{Validation: spreadsheet.Validation{Type: spreadsheet.ValidateTextLength}, WantErr: spreadsheet.ErrInvalidValidation},
+		{Validation: spreadsheet.Validation{Type: spreadsheet.ValidateList}, WantErr: spreadsheet.ErrInvalidValidation},
+	} {
+		lo, hi, err := tc.Validation.Bounds()
+		if tc.WantErr != nil {
+			if !errors.Is(err, tc.WantErr) {
+				t.Errorf("%+v: got %+v, wanted %v", tc.Validation, err, tc.WantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%+v: %+v", tc.Validation, err)
+		} else if lo != tc.WantLo || hi != tc.WantHi {
+			t.Errorf("%+v: got %q..%q, wanted %q..%q", tc.Validation, lo, hi, tc.WantLo, tc.WantHi)
+		}
+	}
+}
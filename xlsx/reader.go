@@ -0,0 +1,69 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package xlsx
+
+import (
+	"context"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/UNO-SOFT/spreadsheet"
+)
+
+var _ = (spreadsheet.Reader)((*XLSXReader)(nil))
+
+// XLSXReader reads an xlsx file.
+type XLSXReader struct {
+	xl *excelize.File
+}
+
+// NewReader returns a spreadsheet.Reader for the xlsx file.
+//
+// The cell values are the raw values: dates are date serial numbers,
+// and booleans are "1" or "0".
+//
+// An encrypted workbook needs the WithPassword option.
+//
+// This reader reads everything in memory.
+func NewReader(r io.Reader, opts ...Option) (*XLSXReader, error) {
+	o := newOptions(opts)
+	xl, err := excelize.OpenReader(r, excelize.Options{RawCellValue: true, Password: o.password})
+	if err != nil {
+		return nil, err
+	}
+	return &XLSXReader{xl: xl}, nil
+}
+
+// Close the reader, removing the temporary files.
+func (xr *XLSXReader) Close() error { return xr.xl.Close() }
+
+// Sheets returns the names of the sheets.
+func (xr *XLSXReader) Sheets() []string { return xr.xl.GetSheetList() }
+
+// ReadRows calls fn with each row of the sheet.
+func (xr *XLSXReader) ReadRows(ctx context.Context, sheetName string, fn func(row []string) error) error {
+	rows, err := xr.xl.Rows(sheetName)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+		row, err := rows.Columns(excelize.Options{RawCellValue: true})
+		if err != nil {
+			return err
+		}
+		if err = fn(row); err != nil {
+			return err
+		}
+	}
+	if err = rows.Error(); err != nil {
+		return err
+	}
+	return rows.Close()
+}
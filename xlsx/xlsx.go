@@ -9,23 +9,60 @@ import (
 	"database/sql/driver"
 	"fmt"
 	"io"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/UNO-SOFT/spreadsheet"
+	"github.com/UNO-SOFT/spreadsheet/internal/header"
 	"github.com/xuri/excelize/v2"
 )
 
 var _ = (spreadsheet.Writer)((*XLSXWriter)(nil))
+var _ = (spreadsheet.Protector)((*XLSXWriter)(nil))
+var _ = (spreadsheet.ConditionalFormatter)((*XLSXSheet)(nil))
+var _ = (spreadsheet.CellMerger)((*XLSXSheet)(nil))
+
+func init() {
+	spreadsheet.Register(spreadsheet.Format{
+		Name: "xlsx", Extensions: []string{".xlsx"},
+		MIMETypes: []string{"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+		New: func(w io.Writer, _ spreadsheet.Options) (spreadsheet.Writer, error) {
+			return NewWriter(w), nil
+		},
+	})
+}
+
+// Option is an option for the xlsx writer and reader.
+type Option func(*options)
+
+type options struct {
+	password string
+}
+
+// WithPassword encrypts the written workbook with the password (ECMA-376 agile encryption),
+// or decrypts the read workbook.
+func WithPassword(password string) Option { return func(o *options) { o.password = password } }
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, f := range opts {
+		f(&o)
+	}
+	return o
+}
 
 type XLSXWriter struct {
 	w      io.Writer
 	xl     *excelize.File
+	opts   options
 	styles map[string]int
 	sheets []string
-	mu     sync.Mutex
+	// protections of the sheets not created yet
+	protections map[string]spreadsheet.SheetProtection
+	mu          sync.Mutex
 }
 
 type XLSXSheet struct {
@@ -40,8 +77,8 @@ type XLSXSheet struct {
 // This writer allows concurrent writes to separate sheets.
 //
 // This writer collects everything in memory, so big sheets may impose problems.
-func NewWriter(w io.Writer) *XLSXWriter {
-	return &XLSXWriter{w: w, xl: excelize.NewFile()}
+func NewWriter(w io.Writer, opts ...Option) *XLSXWriter {
+	return &XLSXWriter{w: w, xl: excelize.NewFile(), opts: newOptions(opts)}
 }
 
 func (xlw *XLSXWriter) Close() error {
@@ -55,10 +92,34 @@ func (xlw *XLSXWriter) Close() error {
 	if xl == nil || w == nil {
 		return nil
 	}
-	_, err := xl.WriteTo(w)
+	_, err := xl.WriteTo(w, excelize.Options{Password: xlw.opts.password})
 	return err
 }
+
+// NewSheet adds a new sheet.
+//
+// Excel splits the List of a Validation at the commas, so the items with a comma
+// are rejected with ErrInvalidValidation: use a ListRange for them.
 func (xlw *XLSXWriter) NewSheet(name string, columns []spreadsheet.Column) (spreadsheet.Sheet, error) {
+	headerRows := header.Rows(columns)
+	firstRow := len(headerRows) + 1
+	// the validations are checked before the sheet is created
+	dvs := make([]*excelize.DataValidation, len(columns))
+	for i, c := range columns {
+		if err := c.Check(); err != nil {
+			return nil, err
+		}
+		if c.Validation == nil {
+			continue
+		}
+		col, err := excelize.ColumnNumberToName(i + 1)
+		if err != nil {
+			return nil, err
+		}
+		if dvs[i], err = newDataValidation(fmt.Sprintf("%s%d:%s%d", col, firstRow, col, MaxRowCount), *c.Validation); err != nil {
+			return nil, fmt.Errorf("%s[%s]: %w", name, col, err)
+		}
+	}
 	xlw.mu.Lock()
 	defer xlw.mu.Unlock()
 	xlw.sheets = append(xlw.sheets, name)
@@ -67,53 +128,168 @@ func (xlw *XLSXWriter) NewSheet(name string, columns []spreadsheet.Column) (spre
 	} else {
 		xlw.xl.NewSheet(name)
 	}
-	var hasHeader bool
 	for i, c := range columns {
 		col, err := excelize.ColumnNumberToName(i + 1)
 		if err != nil {
 			return nil, err
 		}
+		if dvs[i] != nil {
+			if err = xlw.xl.AddDataValidation(name, dvs[i]); err != nil {
+				return nil, fmt.Errorf("%s[%s]: %w", name, col, err)
+			}
+		}
 		if s := xlw.getStyle(c.Column); s != 0 {
 			if err = xlw.xl.SetColStyle(name, col, s); err != nil {
 				return nil, err
 			}
 		}
-		if s := xlw.getStyle(c.Header); s != 0 {
-			if err = xlw.xl.SetCellStyle(name, col+"1", col+"1", s); err != nil {
+	}
+	for k, row := range headerRows {
+		for i, c := range row {
+			if c.Covered {
+				continue
+			}
+			axis, err := excelize.CoordinatesToCellName(i+1, k+1)
+			if err != nil {
 				return nil, err
 			}
+			if s := xlw.getStyle(c.Style); s != 0 {
+				if err = xlw.xl.SetCellStyle(name, axis, axis, s); err != nil {
+					return nil, err
+				}
+			}
+			if c.Text != "" {
+				if err = xlw.xl.SetCellStr(name, axis, c.Text); err != nil {
+					return nil, err
+				}
+			}
+			if c.ColSpan > 1 || c.RowSpan > 1 {
+				last, err := excelize.CoordinatesToCellName(i+c.ColSpan, k+c.RowSpan)
+				if err != nil {
+					return nil, err
+				}
+				if err = xlw.xl.MergeCell(name, axis, last); err != nil {
+					return nil, err
+				}
+			}
 		}
-		if c.Name != "" {
-			hasHeader = true
-			if err = xlw.xl.SetCellStr(name, col+"1", c.Name); err != nil {
-				return nil, err
+	}
+	if p, ok := xlw.protections[name]; ok {
+		delete(xlw.protections, name)
+		if err := xlw.protectSheet(name, p); err != nil {
+			return nil, err
+		}
+	}
+	return &XLSXSheet{xl: xlw.xl, Name: name, row: int64(len(headerRows))}, nil
+}
+
+// newDataValidation returns the data validation of the cells of the sqref.
+func newDataValidation(sqref string, v spreadsheet.Validation) (*excelize.DataValidation, error) {
+	lo, hi, err := v.Bounds()
+	if err != nil {
+		return nil, err
+	}
+	dv := excelize.NewDataValidation(true)
+	dv.Sqref = sqref
+	if v.Type == spreadsheet.ValidateList {
+		if len(v.List) != 0 {
+			for _, s := range v.List {
+				if strings.ContainsRune(s, ',') {
+					return nil, fmt.Errorf("%w: list item %q contains a comma", spreadsheet.ErrInvalidValidation, s)
+				}
 			}
+			err = dv.SetDropList(v.List)
+		} else {
+			dv.SetSqrefDropList(v.ListRange)
+		}
+	} else {
+		var typ excelize.DataValidationType
+		switch v.Type {
+		case spreadsheet.ValidateWhole:
+			typ = excelize.DataValidationTypeWhole
+		case spreadsheet.ValidateDecimal:
+			typ = excelize.DataValidationTypeDecimal
+		case spreadsheet.ValidateDate:
+			typ = excelize.DataValidationTypeDate
+		case spreadsheet.ValidateTextLength:
+			typ = excelize.DataValidationTypeTextLength
 		}
+		switch {
+		case lo != "" && hi != "":
+			err = dv.SetRange(lo, hi, typ, excelize.DataValidationOperatorBetween)
+		case lo != "":
+			err = dv.SetRange(lo, "", typ, excelize.DataValidationOperatorGreaterThanOrEqual)
+		default:
+			err = dv.SetRange(hi, "", typ, excelize.DataValidationOperatorLessThanOrEqual)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", spreadsheet.ErrInvalidValidation, err)
 	}
-	xls := &XLSXSheet{xl: xlw.xl, Name: name}
-	if hasHeader {
-		xls.row++
+	if v.ErrorTitle != "" || v.ErrorMessage != "" {
+		dv.SetError(excelize.DataValidationErrorStyleStop, v.ErrorTitle, v.ErrorMessage)
 	}
-	return xls, nil
+	return dv, nil
+}
+
+// ProtectWorkbook protects the structure of the workbook.
+func (xlw *XLSXWriter) ProtectWorkbook(password string) error {
+	xlw.mu.Lock()
+	defer xlw.mu.Unlock()
+	return xlw.xl.ProtectWorkbook(&excelize.WorkbookProtectionOptions{
+		AlgorithmName: passwordAlgorithm(password), Password: password, LockStructure: true,
+	})
+}
+
+// ProtectSheet protects the sheet - it can be called after NewSheet, too.
+func (xlw *XLSXWriter) ProtectSheet(name string, p spreadsheet.SheetProtection) error {
+	xlw.mu.Lock()
+	defer xlw.mu.Unlock()
+	if slices.Contains(xlw.sheets, name) {
+		return xlw.protectSheet(name, p)
+	}
+	if xlw.protections == nil {
+		xlw.protections = make(map[string]spreadsheet.SheetProtection)
+	}
+	xlw.protections[name] = p
+	return nil
+}
+
+func (xlw *XLSXWriter) protectSheet(name string, p spreadsheet.SheetProtection) error {
+	return xlw.xl.ProtectSheet(name, &excelize.SheetProtectionOptions{
+		AlgorithmName: passwordAlgorithm(p.Password), Password: p.Password,
+		SelectLockedCells: true, SelectUnlockedCells: true,
+		Sort: p.AllowSort, AutoFilter: p.AllowFilter,
+		InsertRows: p.AllowInsertRows, DeleteRows: p.AllowDeleteRows,
+		InsertColumns: p.AllowInsertColumns, DeleteColumns: p.AllowDeleteColumns,
+	})
+}
+
+// passwordAlgorithm returns the password hash algorithm: SHA-512, if there is a password.
+func passwordAlgorithm(password string) string {
+	if password == "" {
+		return ""
+	}
+	return "SHA-512"
 }
 
 func (xlw *XLSXWriter) getStyle(style spreadsheet.Style) int {
-	if !style.FontBold && style.Format == "" {
+	if style == (spreadsheet.Style{}) {
 		return 0
 	}
-	k := fmt.Sprintf("%t\t%s", style.FontBold, style.Format)
+	k := fmt.Sprintf("%t\t%t\t%s\t%s\t%s", style.FontBold, style.Unlocked, style.FontColor, style.BackgroundColor, style.Format)
 	s, ok := xlw.styles[k]
 	if ok {
 		return s
 	}
-	var st excelize.Style
-	if style.FontBold {
-		st.Font = &excelize.Font{Bold: true}
-	}
+	st := newStyle(style)
 	if style.Format != "" {
 		st.CustomNumFmt = &style.Format
 	}
-	s, err := xlw.xl.NewStyle(&st)
+	if style.Unlocked {
+		st.Protection = &excelize.Protection{Locked: false}
+	}
+	s, err := xlw.xl.NewStyle(st)
 	if err != nil {
 		panic(err)
 	}
@@ -124,10 +300,90 @@ func (xlw *XLSXWriter) getStyle(style spreadsheet.Style) int {
 	return s
 }
 
+// newStyle returns the excelize.Style with the font and fill of the style.
+func newStyle(style spreadsheet.Style) *excelize.Style {
+	var st excelize.Style
+	if style.FontBold || style.FontColor != "" {
+		st.Font = &excelize.Font{Bold: style.FontBold, Color: style.FontColor}
+	}
+	if style.BackgroundColor != "" {
+		st.Fill = excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{style.BackgroundColor}}
+	}
+	return &st
+}
+
 // MaxRowCount is the number of maximum rows.
 const MaxRowCount = 1_048_576
 
 func (xls *XLSXSheet) Close() error { return nil }
+
+// MergeCells merges the cells of the range, as described at spreadsheet.CellMerger.
+func (xls *XLSXSheet) MergeCells(ref string) error {
+	first, last, ok := strings.Cut(ref, ":")
+	if !ok {
+		return fmt.Errorf("MergeCells(%q): not a range", ref)
+	}
+	var rows [2]int
+	for i, cell := range []string{first, last} {
+		var err error
+		if _, rows[i], err = excelize.CellNameToCoordinates(cell); err != nil {
+			return fmt.Errorf("MergeCells(%q): %w", ref, err)
+		}
+	}
+	xls.mu.Lock()
+	defer xls.mu.Unlock()
+	if int64(min(rows[0], rows[1])) <= xls.row {
+		return fmt.Errorf("MergeCells(%q): the first %d rows are already appended", ref, xls.row)
+	}
+	return xls.xl.MergeCell(xls.Name, first, last)
+}
+
+// AddConditionalFormat adds the conditional format to the cells of the range.
+func (xls *XLSXSheet) AddConditionalFormat(ref string, cf spreadsheet.ConditionalFormat) error {
+	if err := cf.Check(); err != nil {
+		return err
+	}
+	var opts excelize.ConditionalFormatOptions
+	switch cf.Type {
+	case spreadsheet.ConditionCellValue:
+		opts.Type, opts.Value = "cell", cf.Value
+		switch cf.Operator {
+		case spreadsheet.OperatorEqual:
+			opts.Criteria = "=="
+		case spreadsheet.OperatorNotEqual:
+			opts.Criteria = "!="
+		case spreadsheet.OperatorBetween, spreadsheet.OperatorNotBetween:
+			opts.Criteria, opts.MinValue, opts.MaxValue = cf.Operator.String(), cf.Value, cf.Value2
+		default:
+			opts.Criteria = cf.Operator.String()
+		}
+	case spreadsheet.ConditionFormula:
+		opts.Type, opts.Criteria = "formula", cf.Formula
+	case spreadsheet.ConditionColorScale:
+		opts.Type, opts.Criteria = "2_color_scale", "="
+		opts.MinType, opts.MinColor = "min", cf.MinColor
+		opts.MaxType, opts.MaxColor = "max", cf.MaxColor
+		if cf.MidColor != "" {
+			opts.Type = "3_color_scale"
+			opts.MidType, opts.MidValue, opts.MidColor = "percentile", "50", cf.MidColor
+		}
+	case spreadsheet.ConditionDataBar:
+		opts.Type, opts.Criteria = "data_bar", "="
+		opts.MinType, opts.MaxType, opts.BarColor = "min", "max", cf.BarColor
+	}
+	if cf.Type == spreadsheet.ConditionCellValue || cf.Type == spreadsheet.ConditionFormula {
+		format, err := xls.xl.NewConditionalStyle(newStyle(spreadsheet.Style{
+			FontBold: cf.Style.FontBold, FontColor: cf.Style.FontColor, BackgroundColor: cf.Style.BackgroundColor,
+		}))
+		if err != nil {
+			return err
+		}
+		opts.Format = &format
+	}
+	xls.mu.Lock()
+	defer xls.mu.Unlock()
+	return xls.xl.SetConditionalFormat(xls.Name, ref, []excelize.ConditionalFormatOptions{opts})
+}
 func (xls *XLSXSheet) AppendRow(values ...any) error {
 	xls.mu.Lock()
 	defer xls.mu.Unlock()
@@ -0,0 +1,435 @@
+// Copyright 2026 Tamás Gulácsi.
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package xlsx_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/UNO-SOFT/spreadsheet"
+	"github.com/UNO-SOFT/spreadsheet/spreadsheettest"
+	"github.com/UNO-SOFT/spreadsheet/xlsx"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestConformance(t *testing.T) {
+	spreadsheettest.Run(t, spreadsheettest.Config{
+		New:     func(w io.Writer) (spreadsheet.Writer, error) { return xlsx.NewWriter(w), nil },
+		Read:    func(b []byte) (spreadsheet.Reader, error) { return xlsx.NewReader(bytes.NewReader(b)) },
+		MaxRows: xlsx.MaxRowCount,
+		Style:   cellStyle,
+	})
+}
+
+// cellStyle returns the style of the cell.
+func cellStyle(data []byte, sheet string, row, col int) (spreadsheet.Style, error) {
+	var s spreadsheet.Style
+	f, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		return s, err
+	}
+	defer f.Close()
+	axis, err := excelize.CoordinatesToCellName(col+1, row+1)
+	if err != nil {
+		return s, err
+	}
+	id, err := f.GetCellStyle(sheet, axis)
+	if err != nil {
+		return s, err
+	}
+	st, err := f.GetStyle(id)
+	if err != nil {
+		return s, err
+	}
+	if st.Font != nil {
+		s.FontBold = st.Font.Bold
+		if st.Font.Color != "" {
+			s.FontColor = "#" + strings.TrimPrefix(st.Font.Color, "#")
+		}
+	}
+	if len(st.Fill.Color) != 0 {
+		s.BackgroundColor = "#" + strings.TrimPrefix(st.Fill.Color[0], "#")
+	}
+	return s, nil
+}
+
+func TestPassword(t *testing.T) {
+	const password = "Titkos jelszó"
+	var buf bytes.Buffer
+	w := xlsx.NewWriter(&buf, xlsx.WithPassword(password))
+	sheet, err := w.NewSheet("Salaries", []spreadsheet.Column{{Name: "name"}, {Name: "salary"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = sheet.AppendRow("Kovács", 1_000_000); err != nil {
+		t.Fatal(err)
+	}
+	if err = sheet.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	b := buf.Bytes()
+	// an encrypted workbook is an OLE compound file, not a zip
+	if !bytes.HasPrefix(b, []byte("\xD0\xCF\x11\xE0\xA1\xB1\x1A\xE1")) {
+		t.Errorf("not an OLE compound file: % x", b[:min(len(b), 8)])
+	}
+
+	if r, err := xlsx.NewReader(bytes.NewReader(b)); err == nil {
+		r.Close()
+		t.Error("opened without password")
+	}
+	if r, err := xlsx.NewReader(bytes.NewReader(b), xlsx.WithPassword("bad")); err == nil {
+		r.Close()
+		t.Error("opened with a bad password")
+	}
+	r, err := xlsx.NewReader(bytes.NewReader(b), xlsx.WithPassword(password))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	var got [][]string
+	if err = r.ReadRows(context.Background(), "Salaries", func(row []string) error {
+		got = append(got, slices.Clone(row))
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if want := [][]string{{"name", "salary"}, {"Kovács", "1000000"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+func TestProtection(t *testing.T) {
+	var buf bytes.Buffer
+	w := xlsx.NewWriter(&buf)
+	if err := w.ProtectWorkbook("secret"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.ProtectSheet("Protected", spreadsheet.SheetProtection{Password: "secret", AllowSort: true}); err != nil {
+		t.Fatal(err)
+	}
+	sheet, err := w.NewSheet("Protected", []spreadsheet.Column{
+		{Name: "input", Column: spreadsheet.Style{Unlocked: true}}, {Name: "fixed"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = sheet.AppendRow("x", 1); err != nil {
+		t.Fatal(err)
+	}
+	if err = sheet.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = w.NewSheet("Later", nil); err != nil {
+		t.Fatal(err)
+	}
+	// after NewSheet, too
+	if err = w.ProtectSheet("Later", spreadsheet.SheetProtection{}); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, wants := range map[string][]string{
+		"xl/workbook.xml":          {`<workbookProtection lockStructure="true" workbookAlgorithmName="SHA-512"`},
+		"xl/worksheets/sheet1.xml": {`<sheetProtection algorithmName="SHA-512"`, `sheet="true"`},
+		"xl/worksheets/sheet2.xml": {`<sheetProtection`},
+		"xl/styles.xml":            {`locked="false"`},
+	} {
+		rc, err := zr.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range wants {
+			if !bytes.Contains(b, []byte(want)) {
+				t.Errorf("%s: no %s in %s", name, want, b)
+			}
+		}
+		if name == "xl/worksheets/sheet1.xml" && bytes.Contains(b, []byte(`sort="true"`)) {
+			t.Errorf("%s: sort is not allowed", name)
+		}
+	}
+}
+
+func TestDataValidation(t *testing.T) {
+	var buf bytes.Buffer
+	w := xlsx.NewWriter(&buf)
+	if _, err := w.NewSheet("Bad", []spreadsheet.Column{
+		{Name: "a", Validation: &spreadsheet.Validation{Type: spreadsheet.ValidateDate, Min: "tomorrow"}},
+	}); !errors.Is(err, spreadsheet.ErrInvalidValidation) {
+		t.Errorf("got %+v, wanted ErrInvalidValidation", err)
+	}
+	if _, err := w.NewSheet("Comma", []spreadsheet.Column{
+		{Name: "a", Validation: &spreadsheet.Validation{Type: spreadsheet.ValidateList, List: []string{"Smith, John", "Doe"}}},
+	}); !errors.Is(err, spreadsheet.ErrInvalidValidation) {
+		t.Errorf("got %+v, wanted ErrInvalidValidation for a comma", err)
+	}
+	sheet, err := w.NewSheet("Input", []spreadsheet.Column{
+		{Name: "choice", Validation: &spreadsheet.Validation{
+			Type: spreadsheet.ValidateList, List: []string{"yes", "no"},
+			ErrorTitle: "Invalid", ErrorMessage: "Choose from the list",
+		}},
+		{Name: "count", Validation: &spreadsheet.Validation{Type: spreadsheet.ValidateWhole, Min: 1, Max: 10}},
+		{Name: "code", Validation: &spreadsheet.Validation{Type: spreadsheet.ValidateTextLength, Max: 8}},
+		{Name: "listed", Validation: &spreadsheet.Validation{Type: spreadsheet.ValidateList, ListRange: "'Code lists'!$A$2:$A$3"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = sheet.AppendRow("yes", 1, "x"); err != nil {
+		t.Fatal(err)
+	}
+	if err = sheet.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := excelize.OpenReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	dvs, err := f.GetDataValidations("Input")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]*excelize.DataValidation, len(dvs))
+	for _, dv := range dvs {
+		got[dv.Sqref] = dv
+	}
+	for sqref, want := range map[string]excelize.DataValidation{
+		"A2:A1048576": {Type: "list", Formula1: `"yes,no"`, ErrorTitle: ptr("Invalid"), Error: ptr("Choose from the list")},
+		"B2:B1048576": {Type: "whole", Operator: "between", Formula1: "1", Formula2: "10"},
+		"C2:C1048576": {Type: "textLength", Operator: "lessThanOrEqual", Formula1: "8"},
+		"D2:D1048576": {Type: "list", Formula1: "'Code lists'!$A$2:$A$3"},
+	} {
+		dv := got[sqref]
+		if dv == nil {
+			t.Errorf("%s: no validation in %+v", sqref, dvs)
+			continue
+		}
+		if dv.Type != want.Type || dv.Operator != want.Operator && want.Operator != "" ||
+			dv.Formula1 != want.Formula1 || dv.Formula2 != want.Formula2 {
+			t.Errorf("%s: got %+v, wanted %+v", sqref, dv, want)
+		}
+		if want.Error != nil && (dv.Error == nil || *dv.Error != *want.Error ||
+			dv.ErrorTitle == nil || *dv.ErrorTitle != *want.ErrorTitle) {
+			t.Errorf("%s: got error %v/%v, wanted %q/%q", sqref, dv.ErrorTitle, dv.Error, *want.ErrorTitle, *want.Error)
+		}
+	}
+}
+
+func TestDataValidationRollback(t *testing.T) {
+	var buf bytes.Buffer
+	w := xlsx.NewWriter(&buf)
+	ok := spreadsheet.Column{Name: "ok", Validation: &spreadsheet.Validation{Type: spreadsheet.ValidateWhole, Min: 1, Max: 2}}
+	if _, err := w.NewSheet("Retried", []spreadsheet.Column{
+		ok,
+		{Name: "long", Validation: &spreadsheet.Validation{Type: spreadsheet.ValidateList, List: []string{strings.Repeat("x", 256)}}},
+	}); !errors.Is(err, spreadsheet.ErrInvalidValidation) {
+		t.Errorf("got %+v, wanted ErrInvalidValidation", err)
+	}
+	sheet, err := w.NewSheet("Retried", []spreadsheet.Column{ok})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = sheet.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := excelize.OpenReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if got := f.GetSheetList(); !reflect.DeepEqual(got, []string{"Retried"}) {
+		t.Errorf("got sheets %q", got)
+	}
+	dvs, err := f.GetDataValidations("Retried")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dvs) != 1 || dvs[0].Sqref != "A2:A1048576" {
+		t.Errorf("got %+v, wanted the validation of A", dvs)
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestConditionalFormat(t *testing.T) {
+	var buf bytes.Buffer
+	w := xlsx.NewWriter(&buf)
+	sheet, err := w.NewSheet("Amounts", []spreadsheet.Column{
+		{Name: "amount", Column: spreadsheet.Style{FontColor: "#000080", BackgroundColor: "#EEEEEE"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cf := sheet.(spreadsheet.ConditionalFormatter)
+	// the rules of the same range would be merged by GetConditionalFormats
+	refs := []string{"A2:A100", "A2:A200", "A2:A300"}
+	for i, c := range []spreadsheet.ConditionalFormat{
+		{
+			Type: spreadsheet.ConditionCellValue, Operator: spreadsheet.OperatorLess, Value: "0",
+			Style: spreadsheet.Style{FontColor: "#C00000"},
+		},
+		{Type: spreadsheet.ConditionFormula, Formula: "A2>100", Style: spreadsheet.Style{FontBold: true}},
+		{Type: spreadsheet.ConditionColorScale, MinColor: "#F8696B", MaxColor: "#63BE7B"},
+	} {
+		if err = cf.AddConditionalFormat(refs[i], c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = sheet.AppendRow(-1); err != nil {
+		t.Fatal(err)
+	}
+	if err = sheet.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := excelize.OpenReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	cfs, err := f.GetConditionalFormats("Amounts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []excelize.ConditionalFormatOptions{
+		{Type: "cell", Criteria: "less than", Value: "0"},
+		{Type: "formula", Criteria: "A2>100"},
+		{Type: "2_color_scale", Criteria: "=", MinColor: "#F8696B", MaxColor: "#63BE7B"},
+	} {
+		if len(cfs[refs[i]]) != 1 {
+			t.Errorf("%s: got %+v, wanted 1 rule", refs[i], cfs[refs[i]])
+			continue
+		}
+		got := cfs[refs[i]][0]
+		if got.Type != want.Type || got.Criteria != want.Criteria || got.Value != want.Value ||
+			got.MinColor != want.MinColor || got.MaxColor != want.MaxColor {
+			t.Errorf("%d. got %+v, wanted %+v", i, got, want)
+		}
+		if i < 2 {
+			if got.Format == nil {
+				t.Errorf("%d. no format", i)
+				continue
+			}
+			st, err := f.GetConditionalStyle(*got.Format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if i == 0 && (st.Font == nil || st.Font.Color != "C00000") {
+				t.Errorf("%d. got font %+v, wanted C00000", i, st.Font)
+			} else if i == 1 && (st.Font == nil || !st.Font.Bold) {
+				t.Errorf("%d. got font %+v, wanted bold", i, st.Font)
+			}
+		}
+	}
+}
+
+func TestMergeCells(t *testing.T) {
+	var buf bytes.Buffer
+	w := xlsx.NewWriter(&buf)
+	sheet, err := w.NewSheet("Quarters", []spreadsheet.Column{
+		{Name: "name"},
+		{Name: "Jan", Groups: []string{"2026", "Q1"}},
+		{Name: "Feb", Groups: []string{"2026", "Q1"}},
+		{Name: "Apr", Groups: []string{"2026", "Q2"}},
+		{Name: "total", Groups: []string{"sum"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := sheet.(spreadsheet.CellMerger)
+	if err = m.MergeCells("A3:B3"); err == nil {
+		t.Error("MergeCells of a header row succeeded")
+	}
+	// the second range is never appended
+	for _, ref := range []string{"A4:B4", "C6:D5"} {
+		if err = m.MergeCells(ref); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = sheet.AppendRow("merged", nil, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err = m.MergeCells("C4:D4"); err == nil {
+		t.Error("MergeCells of an appended row succeeded")
+	}
+	if err = sheet.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := excelize.OpenReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	mcs, err := f.GetMergeCells("Quarters")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]string, len(mcs))
+	for _, mc := range mcs {
+		got[mc.GetStartAxis()+":"+mc.GetEndAxis()] = mc.GetCellValue()
+	}
+	want := map[string]string{
+		"A1:A3": "name", "B1:D1": "2026", "B2:C2": "Q1", "E2:E3": "total", "A4:B4": "merged", "C5:D6": "",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+	rows, err := f.GetRows("Quarters")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 4 || !reflect.DeepEqual(rows[2], []string{"", "Jan", "Feb", "Apr"}) {
+		t.Errorf("got %q", rows)
+	}
+}
+
+func TestInvalidColor(t *testing.T) {
+	w := xlsx.NewWriter(io.Discard)
+	defer w.Close()
+	for _, col := range []spreadsheet.Column{
+		{Name: "header", Header: spreadsheet.Style{FontColor: "red"}},
+		{Name: "column", Column: spreadsheet.Style{BackgroundColor: "#12345"}},
+	} {
+		if _, err := w.NewSheet("Colors", []spreadsheet.Column{col}); !errors.Is(err, spreadsheet.ErrInvalidStyle) {
+			t.Errorf("%s: got %v, wanted %v", col.Name, err, spreadsheet.ErrInvalidStyle)
+		}
+	}
+}